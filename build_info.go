@@ -0,0 +1,202 @@
+package observability
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// redactedValue replaces secrets in the startup event
+const redactedValue = "REDACTED"
+
+// unknownRevision is reported when the binary carries no VCS revision
+const unknownRevision = "unknown"
+
+// BuildInfo is the document served by /buildinfo
+type BuildInfo struct {
+	ServiceName string `json:"service_name"`
+	Version     string `json:"version"`
+	BuildTime   string `json:"build_time"`
+	GoVersion   string `json:"go_version"`
+	// Path is the main package path; empty when the binary has no embedded build info
+	Path string `json:"path,omitempty"`
+	// VCS fields come from the vcs.* build settings (go build -buildvcs)
+	VCSRevision string            `json:"vcs_revision,omitempty"`
+	VCSTime     string            `json:"vcs_time,omitempty"`
+	VCSModified bool              `json:"vcs_modified,omitempty"`
+	Modules     []BuildInfoModule `json:"modules,omitempty"`
+}
+
+// BuildInfoModule is a module compiled into the binary
+type BuildInfoModule struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+	// Replace is the replacement module path, when the module is replaced
+	Replace string `json:"replace,omitempty"`
+}
+
+// NewBuildInfo combines the service metadata in cfg (falling back to the LDFlags values) with
+// the module versions and VCS revision embedded by the Go toolchain
+func NewBuildInfo(cfg BaseConfig) BuildInfo {
+	info := BuildInfo{
+		ServiceName: firstNonEmpty(cfg.ServiceName, GetServiceName()),
+		Version:     firstNonEmpty(cfg.Version, GetVersion()),
+		BuildTime:   firstNonEmpty(cfg.BuildTime, GetBuildTime()),
+		GoVersion:   runtime.Version(),
+	}
+
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	info.Path = bi.Path
+	for _, setting := range bi.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.VCSRevision = setting.Value
+		case "vcs.time":
+			info.VCSTime = setting.Value
+		case "vcs.modified":
+			info.VCSModified = setting.Value == "true"
+		}
+	}
+	for _, dep := range append([]*debug.Module{&bi.Main}, bi.Deps...) {
+		if dep == nil || dep.Path == "" {
+			continue
+		}
+		module := BuildInfoModule{Path: dep.Path, Version: dep.Version}
+		if dep.Replace != nil {
+			module.Replace = dep.Replace.Path
+			module.Version = dep.Replace.Version
+		}
+		info.Modules = append(info.Modules, module)
+	}
+	return info
+}
+
+// firstNonEmpty returns the first value that is not blank
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// BuildInfoHandler serves NewBuildInfo(cfg) as JSON
+func BuildInfoHandler(cfg BaseConfig) http.Handler {
+	info := NewBuildInfo(cfg)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(info)
+	})
+}
+
+// registerBuildInfoMetric exports info as the service.build_info gauge (service_build_info in
+// Prometheus), always 1, so dashboards can join on the version labels
+func registerBuildInfoMetric(info BuildInfo, mp metric.MeterProvider) {
+	attrs := metric.WithAttributeSet(attribute.NewSet(
+		attribute.String("version", info.Version),
+		attribute.String("build_time", info.BuildTime),
+		attribute.String("go_version", info.GoVersion),
+		attribute.String("revision", info.revision()),
+	))
+	_, err := mp.Meter("build-info").Int64ObservableGauge(
+		"service.build_info",
+		metric.WithDescription("Build information of the service; the value is always 1"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(1, attrs)
+			return nil
+		}),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+}
+
+// revision returns the VCS revision, or unknownRevision without one
+func (info BuildInfo) revision() string {
+	return firstNonEmpty(info.VCSRevision, unknownRevision)
+}
+
+// logStartup logs the startup event with the build information and the effective configuration.
+// The service and version come from the logger's own fields (see NewLogger).
+func logStartup(logger *Logger, cfg BaseConfig, info BuildInfo) {
+	logger.Info("Service started",
+		"build_time", info.BuildTime,
+		"go_version", info.GoVersion,
+		"revision", info.revision(),
+		"config", EffectiveConfig(cfg),
+	)
+}
+
+// EffectiveConfig returns cfg keyed by environment variable (field name for fields without one),
+// safe to log. Fields tagged redact:"true" are replaced by REDACTED, the values of fields tagged
+// redact:"values" (key=value lists such as OTEL_EXPORTER_HEADERS) are replaced while their keys
+// are kept, and passwords are removed from URLs.
+func EffectiveConfig(cfg BaseConfig) map[string]any {
+	v := reflect.ValueOf(cfg)
+	t := v.Type()
+	out := make(map[string]any, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key := field.Name
+		if env := field.Tag.Get("env"); env != "" {
+			key = env
+		}
+
+		value := v.Field(i).Interface()
+		if s, ok := value.(string); ok && s != "" {
+			switch field.Tag.Get("redact") {
+			case "true":
+				value = redactedValue
+			case "values":
+				value = redactListValues(s)
+			default:
+				value = redactURL(s)
+			}
+		}
+		out[key] = value
+	}
+	return out
+}
+
+// redactListValues keeps the keys of a key=value,... list and replaces every value
+func redactListValues(raw string) string {
+	var parts []string
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		key, _, _ := strings.Cut(item, "=")
+		parts = append(parts, strings.TrimSpace(key)+"="+redactedValue)
+	}
+	return strings.Join(parts, ",")
+}
+
+// redactURL masks the password of URLs carrying credentials and returns other values unchanged
+func redactURL(raw string) string {
+	if !strings.Contains(raw, "@") || !strings.Contains(raw, "://") {
+		return raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil {
+		return raw
+	}
+	return u.Redacted()
+}
@@ -2,11 +2,13 @@ package observability
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"reflect"
 	"strings"
 
 	"github.com/ilyakaznacheev/cleanenv"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // --- Configuration ---
@@ -16,21 +18,52 @@ type MetadataSetter interface {
 }
 
 type BaseConfig struct {
-	ServiceName              string  `env:"SERVICE_NAME"`
-	Version                  string
-	BuildTime                string
-	LogLevel                 string  `env:"LOG_LEVEL" env-default:"info"`
-	OtelEndpoint             string  `env:"OTEL_ENDPOINT" env-default:"localhost:4318"`
-	MetricsPort              int     `env:"METRICS_PORT" env-default:"9090"`
-	OtelTracingSampleRate    float64 `env:"OTEL_TRACING_SAMPLE_RATE" env-default:"1.0"`
-	MetricsMode              string  `env:"METRICS_MODE" env-default:"pull"`
-	MetricsPath              string  `env:"METRICS_PATH" env-default:"/metrics"`
-	MetricsPushEndpoint      string  `env:"METRICS_PUSH_ENDPOINT"`
-	MetricsPushInterval      int     `env:"METRICS_PUSH_INTERVAL" env-default:"30"`
-	MetricsProtocol          string  `env:"METRICS_PROTOCOL" env-default:"http"`
+	ServiceName                    string `env:"SERVICE_NAME"`
+	ServiceNamespace               string `env:"SERVICE_NAMESPACE"`
+	ServiceInstanceID              string `env:"SERVICE_INSTANCE_ID"`
+	DeploymentEnvironment          string `env:"DEPLOYMENT_ENVIRONMENT"`
+	ResourceAttributes             string `env:"RESOURCE_ATTRIBUTES"`
+	Version                        string
+	BuildTime                      string
+	LogLevel                       string   `env:"LOG_LEVEL" env-default:"info"`
+	OtelEndpoint                   string   `env:"OTEL_ENDPOINT" env-default:"localhost:4318"`
+	MetricsPort                    int      `env:"METRICS_PORT" env-default:"9090"`
+	OtelTracingSampleRate          float64  `env:"OTEL_TRACING_SAMPLE_RATE" env-default:"1.0"`
+	MetricsMode                    string   `env:"METRICS_MODE" env-default:"pull"`
+	MetricsPath                    string   `env:"METRICS_PATH" env-default:"/metrics"`
+	MetricsPushEndpoint            string   `env:"METRICS_PUSH_ENDPOINT"`
+	MetricsPushInterval            int      `env:"METRICS_PUSH_INTERVAL" env-default:"30"`
+	MetricsProtocol                string   `env:"METRICS_PROTOCOL" env-default:"http"`
+	MetricsViews                   string   `env:"METRICS_VIEWS"`
+	MetricsViewsFile               string   `env:"METRICS_VIEWS_FILE"`
+	MetricsCardinalityLimit        int      `env:"METRICS_CARDINALITY_LIMIT" env-default:"2000"`
+	MetricsRuntimeEnabled          bool     `env:"METRICS_RUNTIME_ENABLED" env-default:"true"`
+	MetricsProcessEnabled          bool     `env:"METRICS_PROCESS_ENABLED" env-default:"true"`
+	DebugEndpointsEnabled          bool     `env:"DEBUG_ENDPOINTS_ENABLED" env-default:"false"`
+	DebugEndpointsToken            string   `env:"DEBUG_ENDPOINTS_TOKEN" redact:"true"`
+	LogLevelEndpointEnabled        bool     `env:"LOG_LEVEL_ENDPOINT_ENABLED" env-default:"false"`
+	LogsMode                       string   `env:"LOGS_MODE" env-default:"stdout"`
+	LogsEndpoint                   string   `env:"LOGS_ENDPOINT"`
+	LogsProtocol                   string   `env:"LOGS_PROTOCOL" env-default:"http"`
+	LogBaggageKeys                 []string `env:"LOG_BAGGAGE_KEYS" env-separator:","`
+	OtelExporterTLS                bool     `env:"OTEL_EXPORTER_TLS" env-default:"false"`
+	OtelExporterCAFile             string   `env:"OTEL_EXPORTER_CA_FILE"`
+	OtelExporterCertFile           string   `env:"OTEL_EXPORTER_CERT_FILE"`
+	OtelExporterKeyFile            string   `env:"OTEL_EXPORTER_KEY_FILE"`
+	OtelExporterServerName         string   `env:"OTEL_EXPORTER_SERVER_NAME"`
+	OtelExporterHeaders            string   `env:"OTEL_EXPORTER_HEADERS" redact:"values"`
+	OtelExporterCompression        string   `env:"OTEL_EXPORTER_COMPRESSION" env-default:"none"`
+	TracesProtocol                 string   `env:"OTEL_TRACES_PROTOCOL" env-default:"http"`
+	TracesSampler                  string   `env:"OTEL_TRACES_SAMPLER" env-default:"parentbased_traceidratio"`
+	TracesSamplerRules             string   `env:"OTEL_TRACES_SAMPLER_RULES"`
+	Propagators                    []string `env:"OTEL_PROPAGATORS" env-separator:"," env-default:"tracecontext,baggage"`
+	TailSamplingEnabled            bool     `env:"OTEL_TAIL_SAMPLING_ENABLED" env-default:"false"`
+	TailSamplingRate               float64  `env:"OTEL_TAIL_SAMPLING_RATE" env-default:"0.05"`
+	TailSamplingLatencyThresholdMs int      `env:"OTEL_TAIL_SAMPLING_LATENCY_THRESHOLD_MS" env-default:"1000"`
+	TailSamplingMaxSpans           int      `env:"OTEL_TAIL_SAMPLING_MAX_SPANS" env-default:"10000"`
+	TailSamplingMaxTraceAgeMs      int      `env:"OTEL_TAIL_SAMPLING_MAX_TRACE_AGE_MS" env-default:"30000"`
 }
 
-
 func (b *BaseConfig) SetMetadata(s, v, t string) {
 	if s != "" && strings.TrimSpace(b.ServiceName) == "" {
 		b.ServiceName = s
@@ -57,6 +90,69 @@ func (b *BaseConfig) IsHybrid() bool {
 	return mode == "hybrid"
 }
 
+// IsLogsStdout returns true if LogsMode is "stdout" (the default) or "both"
+func (b *BaseConfig) IsLogsStdout() bool {
+	mode := strings.ToLower(strings.TrimSpace(b.LogsMode))
+	return mode == "" || mode == "stdout" || mode == "both"
+}
+
+// IsLogsOTLP returns true if LogsMode is "otlp" or "both"
+func (b *BaseConfig) IsLogsOTLP() bool {
+	mode := strings.ToLower(strings.TrimSpace(b.LogsMode))
+	return mode == "otlp" || mode == "both"
+}
+
+// LogsExportEndpoint returns LogsEndpoint, falling back to OtelEndpoint when unset
+func (b *BaseConfig) LogsExportEndpoint() string {
+	if ep := strings.TrimSpace(b.LogsEndpoint); ep != "" {
+		return ep
+	}
+	return b.OtelEndpoint
+}
+
+// ExporterHeaders parses OtelExporterHeaders ("key1=value1,key2=value2", values URL-encoded
+// as in OTEL_EXPORTER_OTLP_HEADERS) into the header map sent by every OTLP exporter
+func (b *BaseConfig) ExporterHeaders() (map[string]string, error) {
+	return parseKeyValueList("OTEL_EXPORTER_HEADERS", b.OtelExporterHeaders)
+}
+
+// CustomResourceAttributes parses RESOURCE_ATTRIBUTES ("key=value,..." with URL-encoded values)
+// into resource attributes
+func (b *BaseConfig) CustomResourceAttributes() ([]attribute.KeyValue, error) {
+	values, err := parseKeyValueList("RESOURCE_ATTRIBUTES", b.ResourceAttributes)
+	if err != nil {
+		return nil, err
+	}
+	attrs := make([]attribute.KeyValue, 0, len(values))
+	for key, value := range values {
+		attrs = append(attrs, attribute.String(key, value))
+	}
+	return attrs, nil
+}
+
+// parseKeyValueList parses a "key1=value1,key2=value2" list with URL-encoded values, as used by
+// OTEL_EXPORTER_OTLP_HEADERS and OTEL_RESOURCE_ATTRIBUTES; name is the env var used in errors
+func parseKeyValueList(name, raw string) (map[string]string, error) {
+	values := map[string]string{}
+	for _, pair := range strings.Split(raw, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid %s: %q (must be 'key=value' pairs separated by ',')", name, pair)
+		}
+		// PathUnescape keeps '+' (e.g. in base64 credentials), like the OpenTelemetry SDK
+		decoded, err := url.PathUnescape(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %q (%v)", name, pair, err)
+		}
+		values[key] = decoded
+	}
+	return values, nil
+}
+
 func LoadCfg(cfg any) error {
 	// 1. Priority: .env > Environment Variables
 	if _, err := os.Stat(".env"); err == nil {
@@ -69,12 +165,17 @@ func LoadCfg(cfg any) error {
 		}
 	}
 
-	// 2. Inject LDFlags if applicable
+	// 2. Map spec-defined OTEL_* variables, rejecting conflicts with the library keys
+	if err := applyStandardOtelEnv(cfg); err != nil {
+		return err
+	}
+
+	// 3. Inject LDFlags if applicable
 	if ms, ok := cfg.(MetadataSetter); ok {
 		ms.SetMetadata(GetServiceName(), GetVersion(), GetBuildTime())
 	}
 
-	// 3. Post-processing & Validation
+	// 4. Post-processing & Validation
 	return finalizeAndValidate(cfg)
 }
 
@@ -93,6 +194,14 @@ func finalizeAndValidate(cfg any) error {
 		if GetServiceName() != "" && strings.TrimSpace(f.String()) == "" {
 			f.SetString(GetServiceName())
 		}
+		// service.name in OTEL_RESOURCE_ATTRIBUTES is the lowest-precedence source
+		if strings.TrimSpace(f.String()) == "" {
+			if raw, ok := lookupEnv("OTEL_RESOURCE_ATTRIBUTES"); ok {
+				if attrs, err := parseKeyValueList("OTEL_RESOURCE_ATTRIBUTES", raw); err == nil {
+					f.SetString(attrs["service.name"])
+				}
+			}
+		}
 		if strings.TrimSpace(f.String()) == "" {
 			return fmt.Errorf("SERVICE_NAME is required")
 		}
@@ -152,5 +261,218 @@ func finalizeAndValidate(cfg any) error {
 		}
 	}
 
+	// Logic for TracesProtocol validation
+	tpField := v.FieldByName("TracesProtocol")
+	if tpField.IsValid() {
+		tp := strings.ToLower(strings.TrimSpace(tpField.String()))
+		switch tp {
+		case "http", "grpc":
+		default:
+			return fmt.Errorf("invalid OTEL_TRACES_PROTOCOL: %s (must be 'http' or 'grpc')", tp)
+		}
+	}
+
+	// Logic for LogsMode validation
+	lmField := v.FieldByName("LogsMode")
+	if lmField.IsValid() {
+		lm := strings.ToLower(strings.TrimSpace(lmField.String()))
+		switch lm {
+		case "stdout", "otlp", "both":
+		default:
+			return fmt.Errorf("invalid LOGS_MODE: %s (must be 'stdout', 'otlp', or 'both')", lm)
+		}
+	}
+
+	// Logic for LogsProtocol validation
+	lpField := v.FieldByName("LogsProtocol")
+	if lpField.IsValid() {
+		lp := strings.ToLower(strings.TrimSpace(lpField.String()))
+		switch lp {
+		case "http", "grpc":
+		default:
+			return fmt.Errorf("invalid LOGS_PROTOCOL: %s (must be 'http' or 'grpc')", lp)
+		}
+	}
+
+	// Logic for exporter TLS validation
+	if err := validateExporterTLS(v); err != nil {
+		return err
+	}
+
+	// Logic for exporter compression validation
+	ecField := v.FieldByName("OtelExporterCompression")
+	if ecField.IsValid() {
+		ec := strings.ToLower(strings.TrimSpace(ecField.String()))
+		switch ec {
+		case "none", "gzip":
+		default:
+			return fmt.Errorf("invalid OTEL_EXPORTER_COMPRESSION: %s (must be 'none' or 'gzip')", ec)
+		}
+	}
+
+	// Logic for OTEL_RESOURCE_ATTRIBUTES validation
+	if raw, ok := lookupEnv("OTEL_RESOURCE_ATTRIBUTES"); ok {
+		if _, err := parseKeyValueList("OTEL_RESOURCE_ATTRIBUTES", raw); err != nil {
+			return err
+		}
+	}
+
+	// Logic for metric views validation
+	mvField, mvFileField := v.FieldByName("MetricsViews"), v.FieldByName("MetricsViewsFile")
+	if mvField.IsValid() && mvFileField.IsValid() && mvField.Kind() == reflect.String && mvFileField.Kind() == reflect.String {
+		views := BaseConfig{MetricsViews: mvField.String(), MetricsViewsFile: mvFileField.String()}
+		if _, err := views.MetricViews(); err != nil {
+			return err
+		}
+	}
+
+	// Logic for metrics cardinality limit validation
+	if f := v.FieldByName("MetricsCardinalityLimit"); f.IsValid() && f.Kind() == reflect.Int {
+		if f.Int() < 0 {
+			return fmt.Errorf("invalid METRICS_CARDINALITY_LIMIT: %d (must be 0 or greater)", f.Int())
+		}
+	}
+
+	// Logic for debug endpoints validation: the token guards the debug and log level endpoints
+	deField, dtField := v.FieldByName("DebugEndpointsEnabled"), v.FieldByName("DebugEndpointsToken")
+	if deField.IsValid() && dtField.IsValid() && deField.Kind() == reflect.Bool && dtField.Kind() == reflect.String {
+		leField := v.FieldByName("LogLevelEndpointEnabled")
+		logLevelEnabled := leField.IsValid() && leField.Kind() == reflect.Bool && leField.Bool()
+		if dtField.String() != "" && !deField.Bool() && !logLevelEnabled {
+			return fmt.Errorf("invalid DEBUG_ENDPOINTS_TOKEN: set without DEBUG_ENDPOINTS_ENABLED=true or LOG_LEVEL_ENDPOINT_ENABLED=true")
+		}
+	}
+
+	// Logic for RESOURCE_ATTRIBUTES validation
+	raField := v.FieldByName("ResourceAttributes")
+	if raField.IsValid() && raField.Kind() == reflect.String {
+		if _, err := parseKeyValueList("RESOURCE_ATTRIBUTES", raField.String()); err != nil {
+			return err
+		}
+	}
+
+	// Logic for OtelTracingSampleRate validation
+	srField := v.FieldByName("OtelTracingSampleRate")
+	if srField.IsValid() && (srField.Kind() == reflect.Float64 || srField.Kind() == reflect.Float32) {
+		if rate := srField.Float(); rate < 0 || rate > 1 {
+			return fmt.Errorf("invalid OTEL_TRACING_SAMPLE_RATE: %v (must be between 0 and 1)", rate)
+		}
+	}
+
+	// Logic for TracesSamplerRules validation
+	rulesField := v.FieldByName("TracesSamplerRules")
+	if rulesField.IsValid() && rulesField.Kind() == reflect.String {
+		if _, err := parseSamplingRules(rulesField.String()); err != nil {
+			return err
+		}
+	}
+
+	// Logic for tail sampling validation
+	if err := validateTailSampling(v); err != nil {
+		return err
+	}
+
+	// Logic for TracesSampler validation
+	tsField := v.FieldByName("TracesSampler")
+	if tsField.IsValid() {
+		ts := strings.ToLower(strings.TrimSpace(tsField.String()))
+		switch ts {
+		case "", "always_on", "always_off", "traceidratio",
+			"parentbased_always_on", "parentbased_always_off", "parentbased_traceidratio":
+		default:
+			return fmt.Errorf("invalid OTEL_TRACES_SAMPLER: %s (must be 'always_on', 'always_off', 'traceidratio' or their 'parentbased_' variants)", ts)
+		}
+	}
+
+	// Logic for Propagators validation
+	propField := v.FieldByName("Propagators")
+	if propField.IsValid() && propField.Kind() == reflect.Slice {
+		if props, ok := propField.Interface().([]string); ok {
+			if _, err := newTextMapPropagator(props); err != nil {
+				return fmt.Errorf("invalid OTEL_PROPAGATORS: %s (%v)", strings.Join(props, ","), err)
+			}
+		}
+	}
+
+	// Logic for exporter headers validation
+	ehField := v.FieldByName("OtelExporterHeaders")
+	if ehField.IsValid() && ehField.Kind() == reflect.String {
+		if _, err := parseKeyValueList("OTEL_EXPORTER_HEADERS", ehField.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateExporterTLS checks that configured TLS files exist and that the TLS settings are consistent
+func validateExporterTLS(v reflect.Value) error {
+	stringField := func(name string) string {
+		f := v.FieldByName(name)
+		if f.IsValid() && f.Kind() == reflect.String {
+			return strings.TrimSpace(f.String())
+		}
+		return ""
+	}
+
+	files := []struct{ env, path string }{
+		{"OTEL_EXPORTER_CA_FILE", stringField("OtelExporterCAFile")},
+		{"OTEL_EXPORTER_CERT_FILE", stringField("OtelExporterCertFile")},
+		{"OTEL_EXPORTER_KEY_FILE", stringField("OtelExporterKeyFile")},
+	}
+	configured := stringField("OtelExporterServerName") != ""
+	for _, file := range files {
+		if file.path == "" {
+			continue
+		}
+		configured = true
+		info, err := os.Stat(file.path)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %s (file not accessible: %v)", file.env, file.path, err)
+		}
+		if info.IsDir() {
+			return fmt.Errorf("invalid %s: %s (must be a file, not a directory)", file.env, file.path)
+		}
+	}
+
+	if (files[1].path == "") != (files[2].path == "") {
+		return fmt.Errorf("OTEL_EXPORTER_CERT_FILE and OTEL_EXPORTER_KEY_FILE must be set together")
+	}
+
+	tlsField := v.FieldByName("OtelExporterTLS")
+	if configured && tlsField.IsValid() && tlsField.Kind() == reflect.Bool && !tlsField.Bool() {
+		return fmt.Errorf("OTEL_EXPORTER_TLS must be true when exporter certificates or server name are set")
+	}
+
+	return nil
+}
+
+// validateTailSampling checks the tail sampling settings when the processor is enabled
+func validateTailSampling(v reflect.Value) error {
+	enabled := v.FieldByName("TailSamplingEnabled")
+	if !enabled.IsValid() || enabled.Kind() != reflect.Bool || !enabled.Bool() {
+		return nil
+	}
+
+	if f := v.FieldByName("TailSamplingRate"); f.IsValid() && f.Kind() == reflect.Float64 {
+		if rate := f.Float(); rate < 0 || rate > 1 {
+			return fmt.Errorf("invalid OTEL_TAIL_SAMPLING_RATE: %v (must be between 0 and 1)", rate)
+		}
+	}
+	if f := v.FieldByName("TailSamplingLatencyThresholdMs"); f.IsValid() && f.Kind() == reflect.Int {
+		if f.Int() < 0 {
+			return fmt.Errorf("invalid OTEL_TAIL_SAMPLING_LATENCY_THRESHOLD_MS: %d (must be 0 or greater)", f.Int())
+		}
+	}
+	if f := v.FieldByName("TailSamplingMaxSpans"); f.IsValid() && f.Kind() == reflect.Int {
+		if f.Int() <= 0 {
+			return fmt.Errorf("invalid OTEL_TAIL_SAMPLING_MAX_SPANS: %d (must be greater than 0)", f.Int())
+		}
+	}
+	if f := v.FieldByName("TailSamplingMaxTraceAgeMs"); f.IsValid() && f.Kind() == reflect.Int {
+		if f.Int() <= 0 {
+			return fmt.Errorf("invalid OTEL_TAIL_SAMPLING_MAX_TRACE_AGE_MS: %d (must be greater than 0)", f.Int())
+		}
+	}
 	return nil
 }
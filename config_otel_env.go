@@ -0,0 +1,286 @@
+package observability
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// otelEnvBinding maps spec-defined OTEL_* variables onto a BaseConfig field that also has a
+// library-specific key
+type otelEnvBinding struct {
+	// field is the BaseConfig field name
+	field string
+	// key is the library-specific env var for the field
+	key string
+	// standard lists the spec-defined env vars, most specific first
+	standard []string
+	// normalize converts a value of either variable into the field's format
+	normalize func(name, value string) (string, error)
+}
+
+// otelEnvBindings lists the spec-defined variables understood by LoadCfg
+var otelEnvBindings = []otelEnvBinding{
+	{
+		field:     "ServiceName",
+		key:       "SERVICE_NAME",
+		standard:  []string{"OTEL_SERVICE_NAME"},
+		normalize: trimEnvValue,
+	},
+	{
+		field:     "OtelEndpoint",
+		key:       "OTEL_ENDPOINT",
+		standard:  []string{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "OTEL_EXPORTER_OTLP_ENDPOINT"},
+		normalize: otlpEndpointNormalizer("/v1/traces"),
+	},
+	{
+		field:     "MetricsPushEndpoint",
+		key:       "METRICS_PUSH_ENDPOINT",
+		standard:  []string{"OTEL_EXPORTER_OTLP_METRICS_ENDPOINT", "OTEL_EXPORTER_OTLP_ENDPOINT"},
+		normalize: otlpEndpointNormalizer("/v1/metrics"),
+	},
+	{
+		field:     "LogsEndpoint",
+		key:       "LOGS_ENDPOINT",
+		standard:  []string{"OTEL_EXPORTER_OTLP_LOGS_ENDPOINT", "OTEL_EXPORTER_OTLP_ENDPOINT"},
+		normalize: otlpEndpointNormalizer("/v1/logs"),
+	},
+	{
+		field:     "TracesProtocol",
+		key:       "OTEL_TRACES_PROTOCOL",
+		standard:  []string{"OTEL_EXPORTER_OTLP_TRACES_PROTOCOL", "OTEL_EXPORTER_OTLP_PROTOCOL"},
+		normalize: normalizeOtlpProtocol,
+	},
+	{
+		field:     "MetricsProtocol",
+		key:       "METRICS_PROTOCOL",
+		standard:  []string{"OTEL_EXPORTER_OTLP_METRICS_PROTOCOL", "OTEL_EXPORTER_OTLP_PROTOCOL"},
+		normalize: normalizeOtlpProtocol,
+	},
+	{
+		field:     "LogsProtocol",
+		key:       "LOGS_PROTOCOL",
+		standard:  []string{"OTEL_EXPORTER_OTLP_LOGS_PROTOCOL", "OTEL_EXPORTER_OTLP_PROTOCOL"},
+		normalize: normalizeOtlpProtocol,
+	},
+	{
+		field:     "OtelTracingSampleRate",
+		key:       "OTEL_TRACING_SAMPLE_RATE",
+		standard:  []string{"OTEL_TRACES_SAMPLER_ARG"},
+		normalize: normalizeSampleRate,
+	},
+}
+
+// lookupEnv returns the trimmed value of an env var and whether it is set to a non-empty value
+func lookupEnv(name string) (string, bool) {
+	value, ok := os.LookupEnv(name)
+	value = strings.TrimSpace(value)
+	return value, ok && value != ""
+}
+
+// applyStandardOtelEnv copies spec-defined OTEL_* variables into the config.
+// A spec-defined variable fills its field unless the library-specific key is also set; when both
+// are set they must agree, otherwise an error naming both variables is returned.
+func applyStandardOtelEnv(cfg any) error {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	// Endpoint URL schemes decide whether the exporters use TLS
+	schemes := map[string]string{}
+
+	for _, binding := range otelEnvBindings {
+		field := v.FieldByName(binding.field)
+		if !field.IsValid() || !field.CanSet() {
+			continue
+		}
+
+		// The sampler argument only carries a ratio for the ratio-based samplers
+		if binding.field == "OtelTracingSampleRate" && !isRatioSampler(v) {
+			continue
+		}
+
+		name, raw, ok := lookupStandardEnv(binding.standard)
+		if !ok {
+			continue
+		}
+		value, err := binding.normalize(name, raw)
+		if err != nil {
+			return err
+		}
+
+		if keyRaw, set := lookupEnv(binding.key); set {
+			keyValue, err := binding.normalize(binding.key, keyRaw)
+			if err != nil {
+				return err
+			}
+			if keyValue != value {
+				return fmt.Errorf("conflicting %s=%q and %s=%q (set only one, or give both the same value)",
+					binding.key, keyRaw, name, raw)
+			}
+		}
+
+		if err := setEnvField(field, value); err != nil {
+			return fmt.Errorf("invalid %s: %s (%v)", name, raw, err)
+		}
+		if scheme := endpointScheme(raw); scheme != "" {
+			schemes[name] = scheme
+		}
+	}
+
+	return applyEndpointSchemes(v, schemes)
+}
+
+// lookupStandardEnv returns the first set variable among names
+func lookupStandardEnv(names []string) (string, string, bool) {
+	for _, name := range names {
+		if value, ok := lookupEnv(name); ok {
+			return name, value, true
+		}
+	}
+	return "", "", false
+}
+
+// isRatioSampler reports whether the configured sampler (if any) takes a ratio argument
+func isRatioSampler(v reflect.Value) bool {
+	f := v.FieldByName("TracesSampler")
+	if !f.IsValid() || f.Kind() != reflect.String {
+		return true
+	}
+	sampler := strings.ToLower(strings.TrimSpace(f.String()))
+	return sampler == "" || strings.HasSuffix(sampler, "traceidratio")
+}
+
+// setEnvField stores a normalized value into a string or float field
+func setEnvField(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field kind %s", field.Kind())
+	}
+	return nil
+}
+
+// applyEndpointSchemes enables exporter TLS for https endpoint URLs and rejects settings that
+// contradict the URL schemes, since all exporters share one TLS setting
+func applyEndpointSchemes(v reflect.Value, schemes map[string]string) error {
+	var httpsVar, httpVar string
+	for name, scheme := range schemes {
+		if scheme == "https" {
+			httpsVar = name
+		} else {
+			httpVar = name
+		}
+	}
+	if httpsVar == "" && httpVar == "" {
+		return nil
+	}
+	if httpsVar != "" && httpVar != "" {
+		return fmt.Errorf("conflicting endpoint schemes: %s uses https but %s uses http (all exporters share OTEL_EXPORTER_TLS)",
+			httpsVar, httpVar)
+	}
+
+	useTLS := httpsVar != ""
+	if raw, set := lookupEnv("OTEL_EXPORTER_TLS"); set {
+		explicit, err := strconv.ParseBool(raw)
+		if err == nil && explicit != useTLS {
+			name := httpsVar + httpVar
+			return fmt.Errorf("conflicting OTEL_EXPORTER_TLS=%q and the %s scheme of %s", raw, schemes[name], name)
+		}
+	}
+
+	if f := v.FieldByName("OtelExporterTLS"); f.IsValid() && f.CanSet() && f.Kind() == reflect.Bool {
+		f.SetBool(useTLS)
+	}
+	return nil
+}
+
+// trimEnvValue returns the value with surrounding whitespace removed
+func trimEnvValue(_, value string) (string, error) {
+	return strings.TrimSpace(value), nil
+}
+
+// endpointScheme returns the lowercase URL scheme of an endpoint, or "" for host:port values
+func endpointScheme(value string) string {
+	if !strings.Contains(value, "://") {
+		return ""
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Scheme)
+}
+
+// otlpEndpointNormalizer converts OTLP endpoint URLs ("https://collector:4318") into the host:port
+// form used by the exporters. Only the root path and the signal's default path are accepted.
+func otlpEndpointNormalizer(signalPath string) func(name, value string) (string, error) {
+	return func(name, value string) (string, error) {
+		value = strings.TrimSpace(value)
+		if !strings.Contains(value, "://") {
+			return value, nil
+		}
+
+		u, err := url.Parse(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s: %s (%v)", name, value, err)
+		}
+
+		var defaultPort string
+		switch strings.ToLower(u.Scheme) {
+		case "http":
+			defaultPort = "80"
+		case "https":
+			defaultPort = "443"
+		default:
+			return "", fmt.Errorf("invalid %s: %s (scheme must be 'http' or 'https')", name, value)
+		}
+
+		switch strings.TrimSuffix(u.Path, "/") {
+		case "", signalPath:
+		default:
+			return "", fmt.Errorf("invalid %s: %s (custom URL paths are not supported)", name, value)
+		}
+
+		if u.Port() == "" {
+			return net.JoinHostPort(u.Hostname(), defaultPort), nil
+		}
+		return u.Host, nil
+	}
+}
+
+// normalizeOtlpProtocol maps spec protocol names ("grpc", "http/protobuf") onto "grpc"/"http"
+func normalizeOtlpProtocol(name, value string) (string, error) {
+	switch protocol := strings.ToLower(strings.TrimSpace(value)); protocol {
+	case "grpc":
+		return "grpc", nil
+	case "http", "http/protobuf":
+		return "http", nil
+	default:
+		return "", fmt.Errorf("invalid %s: %s (must be 'grpc' or 'http/protobuf')", name, value)
+	}
+}
+
+// normalizeSampleRate parses a sampling ratio so that "1" and "1.0" compare equal
+func normalizeSampleRate(name, value string) (string, error) {
+	rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %s (must be a number)", name, value)
+	}
+	return strconv.FormatFloat(rate, 'g', -1, 64), nil
+}
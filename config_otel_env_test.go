@@ -0,0 +1,295 @@
+package observability
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// otelEnvTestKeys lists every variable read by applyStandardOtelEnv
+var otelEnvTestKeys = []string{
+	"SERVICE_NAME", "OTEL_SERVICE_NAME", "OTEL_RESOURCE_ATTRIBUTES",
+	"OTEL_ENDPOINT", "METRICS_PUSH_ENDPOINT", "LOGS_ENDPOINT",
+	"OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT",
+	"OTEL_EXPORTER_OTLP_METRICS_ENDPOINT", "OTEL_EXPORTER_OTLP_LOGS_ENDPOINT",
+	"OTEL_TRACES_PROTOCOL", "METRICS_PROTOCOL", "LOGS_PROTOCOL",
+	"OTEL_EXPORTER_OTLP_PROTOCOL", "OTEL_EXPORTER_OTLP_TRACES_PROTOCOL",
+	"OTEL_EXPORTER_OTLP_METRICS_PROTOCOL", "OTEL_EXPORTER_OTLP_LOGS_PROTOCOL",
+	"OTEL_TRACING_SAMPLE_RATE", "OTEL_TRACES_SAMPLER", "OTEL_TRACES_SAMPLER_ARG",
+	"OTEL_PROPAGATORS", "OTEL_EXPORTER_TLS", "METRICS_MODE", "LOGS_MODE",
+}
+
+// unsetEnv clears the given variables for the duration of the test
+func unsetEnv(t *testing.T, keys ...string) {
+	t.Helper()
+	for _, key := range keys {
+		if value, ok := os.LookupEnv(key); ok {
+			t.Cleanup(func() { _ = os.Setenv(key, value) })
+		} else {
+			t.Cleanup(func() { _ = os.Unsetenv(key) })
+		}
+		_ = os.Unsetenv(key)
+	}
+}
+
+func TestLoadCfgStandardOtelEnv(t *testing.T) {
+	tests := []struct {
+		name  string
+		env   map[string]string
+		check func(t *testing.T, cfg BaseConfig)
+	}{
+		{
+			name: "OTEL_SERVICE_NAME",
+			env:  map[string]string{"OTEL_SERVICE_NAME": "otel-named"},
+			check: func(t *testing.T, cfg BaseConfig) {
+				if cfg.ServiceName != "otel-named" {
+					t.Errorf("Expected ServiceName 'otel-named', got '%s'", cfg.ServiceName)
+				}
+			},
+		},
+		{
+			name: "Matching Service Names",
+			env:  map[string]string{"SERVICE_NAME": "same", "OTEL_SERVICE_NAME": "same"},
+			check: func(t *testing.T, cfg BaseConfig) {
+				if cfg.ServiceName != "same" {
+					t.Errorf("Expected ServiceName 'same', got '%s'", cfg.ServiceName)
+				}
+			},
+		},
+		{
+			name: "Service Name From Resource Attributes",
+			env:  map[string]string{"OTEL_RESOURCE_ATTRIBUTES": "service.name=from-attrs,team=core"},
+			check: func(t *testing.T, cfg BaseConfig) {
+				if cfg.ServiceName != "from-attrs" {
+					t.Errorf("Expected ServiceName 'from-attrs', got '%s'", cfg.ServiceName)
+				}
+			},
+		},
+		{
+			name: "Base Endpoint Applies To Every Signal",
+			env: map[string]string{
+				"SERVICE_NAME":                "svc",
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "https://collector.example.com",
+			},
+			check: func(t *testing.T, cfg BaseConfig) {
+				for name, got := range map[string]string{
+					"OtelEndpoint":        cfg.OtelEndpoint,
+					"MetricsPushEndpoint": cfg.MetricsPushEndpoint,
+					"LogsEndpoint":        cfg.LogsEndpoint,
+				} {
+					if got != "collector.example.com:443" {
+						t.Errorf("Expected %s 'collector.example.com:443', got '%s'", name, got)
+					}
+				}
+				if !cfg.OtelExporterTLS {
+					t.Error("Expected an https endpoint to enable exporter TLS")
+				}
+			},
+		},
+		{
+			name: "Signal Endpoint Overrides Base Endpoint",
+			env: map[string]string{
+				"SERVICE_NAME":                       "svc",
+				"OTEL_EXPORTER_OTLP_ENDPOINT":        "http://collector:4318",
+				"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "http://traces:4318/v1/traces",
+			},
+			check: func(t *testing.T, cfg BaseConfig) {
+				if cfg.OtelEndpoint != "traces:4318" {
+					t.Errorf("Expected OtelEndpoint 'traces:4318', got '%s'", cfg.OtelEndpoint)
+				}
+				if cfg.MetricsPushEndpoint != "collector:4318" {
+					t.Errorf("Expected MetricsPushEndpoint 'collector:4318', got '%s'", cfg.MetricsPushEndpoint)
+				}
+				if cfg.OtelExporterTLS {
+					t.Error("Expected http endpoints to keep exporter TLS disabled")
+				}
+			},
+		},
+		{
+			name: "Equivalent Endpoints Do Not Conflict",
+			env: map[string]string{
+				"SERVICE_NAME":                "svc",
+				"OTEL_ENDPOINT":               "collector:4318",
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318/",
+			},
+			check: func(t *testing.T, cfg BaseConfig) {
+				if cfg.OtelEndpoint != "collector:4318" {
+					t.Errorf("Expected OtelEndpoint 'collector:4318', got '%s'", cfg.OtelEndpoint)
+				}
+			},
+		},
+		{
+			name: "Protocol Applies To Every Signal",
+			env: map[string]string{
+				"SERVICE_NAME":                "svc",
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc",
+				"METRICS_PROTOCOL":            "grpc",
+			},
+			check: func(t *testing.T, cfg BaseConfig) {
+				if cfg.TracesProtocol != "grpc" || cfg.MetricsProtocol != "grpc" || cfg.LogsProtocol != "grpc" {
+					t.Errorf("Expected gRPC everywhere, got traces=%s metrics=%s logs=%s",
+						cfg.TracesProtocol, cfg.MetricsProtocol, cfg.LogsProtocol)
+				}
+			},
+		},
+		{
+			name: "Signal Protocol Overrides Base Protocol",
+			env: map[string]string{
+				"SERVICE_NAME":                       "svc",
+				"OTEL_EXPORTER_OTLP_PROTOCOL":        "grpc",
+				"OTEL_EXPORTER_OTLP_TRACES_PROTOCOL": "http/protobuf",
+			},
+			check: func(t *testing.T, cfg BaseConfig) {
+				if cfg.TracesProtocol != "http" || cfg.MetricsProtocol != "grpc" {
+					t.Errorf("Expected traces=http metrics=grpc, got traces=%s metrics=%s", cfg.TracesProtocol, cfg.MetricsProtocol)
+				}
+			},
+		},
+		{
+			name: "Sampler Argument",
+			env: map[string]string{
+				"SERVICE_NAME":             "svc",
+				"OTEL_TRACES_SAMPLER":      "parentbased_traceidratio",
+				"OTEL_TRACES_SAMPLER_ARG":  "0.25",
+				"OTEL_TRACING_SAMPLE_RATE": "0.250",
+			},
+			check: func(t *testing.T, cfg BaseConfig) {
+				if cfg.TracesSampler != "parentbased_traceidratio" || cfg.OtelTracingSampleRate != 0.25 {
+					t.Errorf("Unexpected sampler settings: %s %v", cfg.TracesSampler, cfg.OtelTracingSampleRate)
+				}
+			},
+		},
+		{
+			name: "Sampler Argument Ignored For Non-Ratio Sampler",
+			env: map[string]string{
+				"SERVICE_NAME":            "svc",
+				"OTEL_TRACES_SAMPLER":     "always_on",
+				"OTEL_TRACES_SAMPLER_ARG": "ignored",
+			},
+			check: func(t *testing.T, cfg BaseConfig) {
+				if cfg.OtelTracingSampleRate != 1.0 {
+					t.Errorf("Expected default sample rate, got %v", cfg.OtelTracingSampleRate)
+				}
+			},
+		},
+		{
+			name: "Propagators",
+			env:  map[string]string{"SERVICE_NAME": "svc", "OTEL_PROPAGATORS": "tracecontext,baggage,b3"},
+			check: func(t *testing.T, cfg BaseConfig) {
+				if strings.Join(cfg.Propagators, ",") != "tracecontext,baggage,b3" {
+					t.Errorf("Unexpected propagators: %v", cfg.Propagators)
+				}
+			},
+		},
+		{
+			name: "Default Propagators",
+			env:  map[string]string{"SERVICE_NAME": "svc"},
+			check: func(t *testing.T, cfg BaseConfig) {
+				if strings.Join(cfg.Propagators, ",") != "tracecontext,baggage" {
+					t.Errorf("Unexpected default propagators: %v", cfg.Propagators)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unsetEnv(t, otelEnvTestKeys...)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			var cfg BaseConfig
+			if err := LoadCfg(&cfg); err != nil {
+				t.Fatalf("LoadCfg failed: %v", err)
+			}
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestLoadCfgStandardOtelEnvConflicts(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+	}{
+		{
+			name:    "Service Name",
+			env:     map[string]string{"SERVICE_NAME": "a", "OTEL_SERVICE_NAME": "b"},
+			wantErr: "conflicting SERVICE_NAME",
+		},
+		{
+			name:    "Endpoint",
+			env:     map[string]string{"SERVICE_NAME": "svc", "OTEL_ENDPOINT": "a:4318", "OTEL_EXPORTER_OTLP_ENDPOINT": "http://b:4318"},
+			wantErr: "conflicting OTEL_ENDPOINT",
+		},
+		{
+			name:    "Protocol",
+			env:     map[string]string{"SERVICE_NAME": "svc", "METRICS_PROTOCOL": "http", "OTEL_EXPORTER_OTLP_PROTOCOL": "grpc"},
+			wantErr: "conflicting METRICS_PROTOCOL",
+		},
+		{
+			name:    "Sample Rate",
+			env:     map[string]string{"SERVICE_NAME": "svc", "OTEL_TRACING_SAMPLE_RATE": "0.5", "OTEL_TRACES_SAMPLER_ARG": "0.1"},
+			wantErr: "conflicting OTEL_TRACING_SAMPLE_RATE",
+		},
+		{
+			name:    "TLS Disabled With https Endpoint",
+			env:     map[string]string{"SERVICE_NAME": "svc", "OTEL_EXPORTER_TLS": "false", "OTEL_EXPORTER_OTLP_ENDPOINT": "https://collector:4318"},
+			wantErr: "conflicting OTEL_EXPORTER_TLS",
+		},
+		{
+			name: "Mixed Endpoint Schemes",
+			env: map[string]string{
+				"SERVICE_NAME":                        "svc",
+				"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT":  "https://traces:4318",
+				"OTEL_EXPORTER_OTLP_METRICS_ENDPOINT": "http://metrics:4318",
+			},
+			wantErr: "conflicting endpoint schemes",
+		},
+		{
+			name:    "Custom Endpoint Path",
+			env:     map[string]string{"SERVICE_NAME": "svc", "OTEL_EXPORTER_OTLP_ENDPOINT": "http://gateway/otlp"},
+			wantErr: "custom URL paths are not supported",
+		},
+		{
+			name:    "Unsupported Protocol",
+			env:     map[string]string{"SERVICE_NAME": "svc", "OTEL_EXPORTER_OTLP_PROTOCOL": "http/json"},
+			wantErr: "invalid OTEL_EXPORTER_OTLP_PROTOCOL",
+		},
+		{
+			name:    "Unknown Sampler",
+			env:     map[string]string{"SERVICE_NAME": "svc", "OTEL_TRACES_SAMPLER": "jaeger_remote"},
+			wantErr: "invalid OTEL_TRACES_SAMPLER",
+		},
+		{
+			name:    "Unknown Propagator",
+			env:     map[string]string{"SERVICE_NAME": "svc", "OTEL_PROPAGATORS": "tracecontext,carrier-pigeon"},
+			wantErr: "invalid OTEL_PROPAGATORS",
+		},
+		{
+			name:    "Malformed Resource Attributes",
+			env:     map[string]string{"SERVICE_NAME": "svc", "OTEL_RESOURCE_ATTRIBUTES": "team"},
+			wantErr: "invalid OTEL_RESOURCE_ATTRIBUTES",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unsetEnv(t, otelEnvTestKeys...)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			var cfg BaseConfig
+			err := LoadCfg(&cfg)
+			if err == nil {
+				t.Fatalf("Expected LoadCfg to fail with %q", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
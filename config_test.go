@@ -2,6 +2,7 @@ package observability
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -373,3 +374,519 @@ func TestLoadCfgReadsDotEnv(t *testing.T) {
 		t.Errorf("expected MetricsPort 19100, got %d", cfg.MetricsPort)
 	}
 }
+
+func TestLoadCfgLogsMode(t *testing.T) {
+	t.Setenv("SERVICE_NAME", "logs-mode-service")
+	t.Setenv("METRICS_MODE", "pull")
+	t.Setenv("METRICS_PROTOCOL", "http")
+
+	t.Run("Default Logs Mode", func(t *testing.T) {
+		_ = os.Unsetenv("LOGS_MODE")
+		_ = os.Unsetenv("LOGS_PROTOCOL")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+		if cfg.LogsMode != "stdout" {
+			t.Errorf("Expected default LogsMode 'stdout', got '%s'", cfg.LogsMode)
+		}
+		if cfg.LogsProtocol != "http" {
+			t.Errorf("Expected default LogsProtocol 'http', got '%s'", cfg.LogsProtocol)
+		}
+		if !cfg.IsLogsStdout() || cfg.IsLogsOTLP() {
+			t.Error("Expected stdout-only logging by default")
+		}
+	})
+
+	t.Run("Both Logs Mode", func(t *testing.T) {
+		t.Setenv("LOGS_MODE", "both")
+		t.Setenv("LOGS_PROTOCOL", "grpc")
+		t.Setenv("OTEL_ENDPOINT", "collector:4318")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+		if !cfg.IsLogsStdout() || !cfg.IsLogsOTLP() {
+			t.Error("Expected both stdout and OTLP logging for 'both' mode")
+		}
+		// LogsEndpoint falls back to OtelEndpoint when unset
+		if cfg.LogsExportEndpoint() != "collector:4318" {
+			t.Errorf("Expected logs endpoint to fall back to OTEL_ENDPOINT, got '%s'", cfg.LogsExportEndpoint())
+		}
+	})
+
+	t.Run("OTLP Logs Mode With Endpoint", func(t *testing.T) {
+		t.Setenv("LOGS_MODE", "otlp")
+		t.Setenv("LOGS_ENDPOINT", "logs-collector:4318")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+		if cfg.IsLogsStdout() || !cfg.IsLogsOTLP() {
+			t.Error("Expected OTLP-only logging for 'otlp' mode")
+		}
+		if cfg.LogsExportEndpoint() != "logs-collector:4318" {
+			t.Errorf("Expected LOGS_ENDPOINT to be used, got '%s'", cfg.LogsExportEndpoint())
+		}
+	})
+
+	t.Run("Invalid Logs Mode", func(t *testing.T) {
+		t.Setenv("LOGS_MODE", "syslog")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err == nil {
+			t.Error("Expected LoadCfg to fail due to invalid LOGS_MODE")
+		}
+	})
+
+	t.Run("Invalid Logs Protocol", func(t *testing.T) {
+		t.Setenv("LOGS_MODE", "otlp")
+		t.Setenv("LOGS_PROTOCOL", "udp")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err == nil {
+			t.Error("Expected LoadCfg to fail due to invalid LOGS_PROTOCOL")
+		}
+	})
+}
+
+func TestLoadCfgTracesProtocol(t *testing.T) {
+	t.Setenv("SERVICE_NAME", "traces-protocol-service")
+	t.Setenv("METRICS_MODE", "pull")
+	t.Setenv("LOGS_MODE", "stdout")
+
+	t.Run("Defaults", func(t *testing.T) {
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+		if cfg.TracesProtocol != "http" {
+			t.Errorf("Expected default TracesProtocol 'http', got '%s'", cfg.TracesProtocol)
+		}
+		if cfg.OtelExporterCompression != "none" {
+			t.Errorf("Expected default OtelExporterCompression 'none', got '%s'", cfg.OtelExporterCompression)
+		}
+	})
+
+	t.Run("gRPC With Compression", func(t *testing.T) {
+		t.Setenv("OTEL_TRACES_PROTOCOL", "grpc")
+		t.Setenv("OTEL_EXPORTER_COMPRESSION", "gzip")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+		if cfg.TracesProtocol != "grpc" || cfg.OtelExporterCompression != "gzip" {
+			t.Errorf("Unexpected settings: protocol=%s compression=%s", cfg.TracesProtocol, cfg.OtelExporterCompression)
+		}
+	})
+
+	t.Run("Invalid Traces Protocol", func(t *testing.T) {
+		t.Setenv("OTEL_TRACES_PROTOCOL", "thrift")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err == nil {
+			t.Error("Expected LoadCfg to fail due to invalid OTEL_TRACES_PROTOCOL")
+		}
+	})
+
+	t.Run("Invalid Compression", func(t *testing.T) {
+		t.Setenv("OTEL_EXPORTER_COMPRESSION", "zstd")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err == nil {
+			t.Error("Expected LoadCfg to fail due to invalid OTEL_EXPORTER_COMPRESSION")
+		}
+	})
+}
+
+func TestLoadCfgTracesSampler(t *testing.T) {
+	t.Setenv("SERVICE_NAME", "traces-sampler-service")
+	t.Setenv("METRICS_MODE", "pull")
+	t.Setenv("LOGS_MODE", "stdout")
+
+	t.Run("Defaults", func(t *testing.T) {
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+		if cfg.TracesSampler != "parentbased_traceidratio" {
+			t.Errorf("Expected default TracesSampler 'parentbased_traceidratio', got '%s'", cfg.TracesSampler)
+		}
+		if cfg.TracesSamplerRules != "" {
+			t.Errorf("Expected no default TracesSamplerRules, got '%s'", cfg.TracesSamplerRules)
+		}
+	})
+
+	t.Run("Valid Rules", func(t *testing.T) {
+		t.Setenv("OTEL_TRACES_SAMPLER", "always_on")
+		t.Setenv("OTEL_TRACES_SAMPLER_RULES", "/healthz=0,/checkout=1")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+		if cfg.TracesSamplerRules != "/healthz=0,/checkout=1" {
+			t.Errorf("Unexpected TracesSamplerRules: %s", cfg.TracesSamplerRules)
+		}
+	})
+
+	t.Run("Invalid Rules", func(t *testing.T) {
+		t.Setenv("OTEL_TRACES_SAMPLER_RULES", "/checkout=2")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err == nil {
+			t.Error("Expected LoadCfg to fail due to invalid OTEL_TRACES_SAMPLER_RULES")
+		}
+	})
+
+	for _, rate := range []string{"-0.1", "1.5"} {
+		t.Run("Sample Rate Out Of Range "+rate, func(t *testing.T) {
+			t.Setenv("OTEL_TRACING_SAMPLE_RATE", rate)
+
+			var cfg BaseConfig
+			if err := LoadCfg(&cfg); err == nil {
+				t.Errorf("Expected LoadCfg to fail for OTEL_TRACING_SAMPLE_RATE=%s", rate)
+			}
+		})
+	}
+}
+
+func TestLoadCfgTailSampling(t *testing.T) {
+	t.Setenv("SERVICE_NAME", "tail-sampling-service")
+	t.Setenv("METRICS_MODE", "pull")
+	t.Setenv("LOGS_MODE", "stdout")
+
+	t.Run("Defaults", func(t *testing.T) {
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+		if cfg.TailSamplingEnabled {
+			t.Error("Expected tail sampling to be disabled by default")
+		}
+		if cfg.TailSamplingRate != 0.05 || cfg.TailSamplingLatencyThresholdMs != 1000 || cfg.TailSamplingMaxSpans != 10000 || cfg.TailSamplingMaxTraceAgeMs != 30000 {
+			t.Errorf("Unexpected defaults: rate=%v threshold=%d maxSpans=%d maxTraceAge=%d",
+				cfg.TailSamplingRate, cfg.TailSamplingLatencyThresholdMs, cfg.TailSamplingMaxSpans, cfg.TailSamplingMaxTraceAgeMs)
+		}
+	})
+
+	t.Run("Invalid Values Ignored When Disabled", func(t *testing.T) {
+		t.Setenv("OTEL_TAIL_SAMPLING_RATE", "2")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+	})
+
+	invalid := map[string]string{
+		"OTEL_TAIL_SAMPLING_RATE":                 "1.5",
+		"OTEL_TAIL_SAMPLING_LATENCY_THRESHOLD_MS": "-1",
+		"OTEL_TAIL_SAMPLING_MAX_SPANS":            "0",
+		"OTEL_TAIL_SAMPLING_MAX_TRACE_AGE_MS":     "0",
+	}
+	for key, value := range invalid {
+		t.Run("Invalid "+key, func(t *testing.T) {
+			t.Setenv("OTEL_TAIL_SAMPLING_ENABLED", "true")
+			t.Setenv(key, value)
+
+			var cfg BaseConfig
+			if err := LoadCfg(&cfg); err == nil {
+				t.Errorf("Expected LoadCfg to fail for %s=%s", key, value)
+			}
+		})
+	}
+}
+
+func TestLoadCfgResourceFields(t *testing.T) {
+	t.Setenv("SERVICE_NAME", "resource-service")
+	t.Setenv("METRICS_MODE", "pull")
+	t.Setenv("LOGS_MODE", "stdout")
+
+	t.Run("Loaded From Env", func(t *testing.T) {
+		t.Setenv("SERVICE_NAMESPACE", "shop")
+		t.Setenv("SERVICE_INSTANCE_ID", "checkout-1")
+		t.Setenv("DEPLOYMENT_ENVIRONMENT", "staging")
+		t.Setenv("RESOURCE_ATTRIBUTES", "team=checkout,cost.center=cc%2C42")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+		if cfg.ServiceNamespace != "shop" || cfg.ServiceInstanceID != "checkout-1" || cfg.DeploymentEnvironment != "staging" {
+			t.Errorf("Unexpected resource fields: namespace=%s instance=%s environment=%s",
+				cfg.ServiceNamespace, cfg.ServiceInstanceID, cfg.DeploymentEnvironment)
+		}
+
+		attrs, err := cfg.CustomResourceAttributes()
+		if err != nil {
+			t.Fatalf("CustomResourceAttributes failed: %v", err)
+		}
+		got := map[string]string{}
+		for _, attr := range attrs {
+			got[string(attr.Key)] = attr.Value.AsString()
+		}
+		if got["team"] != "checkout" || got["cost.center"] != "cc,42" {
+			t.Errorf("Unexpected custom resource attributes: %v", got)
+		}
+	})
+
+	t.Run("Invalid Resource Attributes", func(t *testing.T) {
+		t.Setenv("RESOURCE_ATTRIBUTES", "team")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err == nil {
+			t.Error("Expected LoadCfg to fail due to invalid RESOURCE_ATTRIBUTES")
+		}
+	})
+}
+
+func TestLoadCfgMetricViews(t *testing.T) {
+	t.Setenv("SERVICE_NAME", "metric-views-service")
+	t.Setenv("METRICS_MODE", "pull")
+	t.Setenv("LOGS_MODE", "stdout")
+
+	t.Run("Valid Views", func(t *testing.T) {
+		t.Setenv("METRICS_VIEWS", `[{"instrument": "rpc.server.duration", "buckets": [0.0001, 0.001]}]`)
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+	})
+
+	t.Run("Invalid Views", func(t *testing.T) {
+		t.Setenv("METRICS_VIEWS", `[{"instrument": "rpc.server.duration", "aggregation": "median"}]`)
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err == nil {
+			t.Error("Expected LoadCfg to fail due to invalid METRICS_VIEWS")
+		}
+	})
+
+	t.Run("Missing Views File", func(t *testing.T) {
+		t.Setenv("METRICS_VIEWS_FILE", filepath.Join(t.TempDir(), "missing.yaml"))
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err == nil {
+			t.Error("Expected LoadCfg to fail due to a missing METRICS_VIEWS_FILE")
+		}
+	})
+}
+
+func TestLoadCfgMetricsCardinalityLimit(t *testing.T) {
+	t.Setenv("SERVICE_NAME", "cardinality-service")
+	t.Setenv("METRICS_MODE", "pull")
+	t.Setenv("LOGS_MODE", "stdout")
+
+	t.Run("Default", func(t *testing.T) {
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+		if cfg.MetricsCardinalityLimit != 2000 {
+			t.Errorf("Expected MetricsCardinalityLimit 2000, got %d", cfg.MetricsCardinalityLimit)
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		t.Setenv("METRICS_CARDINALITY_LIMIT", "0")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+		if cfg.MetricsCardinalityLimit != 0 {
+			t.Errorf("Expected MetricsCardinalityLimit 0, got %d", cfg.MetricsCardinalityLimit)
+		}
+	})
+
+	t.Run("Negative", func(t *testing.T) {
+		t.Setenv("METRICS_CARDINALITY_LIMIT", "-1")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err == nil {
+			t.Error("Expected LoadCfg to fail due to a negative METRICS_CARDINALITY_LIMIT")
+		}
+	})
+}
+
+func TestLoadCfgRuntimeMetrics(t *testing.T) {
+	t.Setenv("SERVICE_NAME", "runtime-metrics-service")
+	t.Setenv("METRICS_MODE", "pull")
+	t.Setenv("LOGS_MODE", "stdout")
+
+	t.Run("Enabled By Default", func(t *testing.T) {
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+		if !cfg.MetricsRuntimeEnabled || !cfg.MetricsProcessEnabled {
+			t.Errorf("Expected runtime and process metrics enabled, got %v/%v", cfg.MetricsRuntimeEnabled, cfg.MetricsProcessEnabled)
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		t.Setenv("METRICS_RUNTIME_ENABLED", "false")
+		t.Setenv("METRICS_PROCESS_ENABLED", "false")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+		if cfg.MetricsRuntimeEnabled || cfg.MetricsProcessEnabled {
+			t.Errorf("Expected runtime and process metrics disabled, got %v/%v", cfg.MetricsRuntimeEnabled, cfg.MetricsProcessEnabled)
+		}
+	})
+}
+
+func TestLoadCfgDebugEndpoints(t *testing.T) {
+	t.Setenv("SERVICE_NAME", "debug-endpoints-service")
+	t.Setenv("METRICS_MODE", "pull")
+	t.Setenv("LOGS_MODE", "stdout")
+
+	t.Run("Enabled With Token", func(t *testing.T) {
+		t.Setenv("DEBUG_ENDPOINTS_ENABLED", "true")
+		t.Setenv("DEBUG_ENDPOINTS_TOKEN", "s3cret")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+		if !cfg.DebugEndpointsEnabled || cfg.DebugEndpointsToken != "s3cret" {
+			t.Errorf("Expected debug endpoints enabled with a token, got %v/%q", cfg.DebugEndpointsEnabled, cfg.DebugEndpointsToken)
+		}
+	})
+
+	t.Run("Token Without Enabled", func(t *testing.T) {
+		t.Setenv("DEBUG_ENDPOINTS_TOKEN", "s3cret")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err == nil {
+			t.Error("Expected LoadCfg to fail due to DEBUG_ENDPOINTS_TOKEN without DEBUG_ENDPOINTS_ENABLED")
+		}
+	})
+
+	t.Run("Token With Log Level Endpoint", func(t *testing.T) {
+		t.Setenv("LOG_LEVEL_ENDPOINT_ENABLED", "true")
+		t.Setenv("DEBUG_ENDPOINTS_TOKEN", "s3cret")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+		if !cfg.LogLevelEndpointEnabled || cfg.DebugEndpointsEnabled {
+			t.Errorf("Expected only the log level endpoint enabled, got %v/%v", cfg.LogLevelEndpointEnabled, cfg.DebugEndpointsEnabled)
+		}
+	})
+}
+
+func TestLoadCfgExporterTLS(t *testing.T) {
+	t.Setenv("SERVICE_NAME", "exporter-tls-service")
+	t.Setenv("METRICS_MODE", "pull")
+	t.Setenv("LOGS_MODE", "stdout")
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client-key.pem")
+	for _, f := range []string{caFile, certFile, keyFile} {
+		if err := os.WriteFile(f, []byte("placeholder"), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", f, err)
+		}
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+		if cfg.OtelExporterTLS {
+			t.Error("Expected exporter TLS to be disabled by default")
+		}
+		headers, err := cfg.ExporterHeaders()
+		if err != nil || len(headers) != 0 {
+			t.Errorf("Expected no exporter headers by default, got %v (%v)", headers, err)
+		}
+	})
+
+	t.Run("mTLS With Headers", func(t *testing.T) {
+		t.Setenv("OTEL_EXPORTER_TLS", "true")
+		t.Setenv("OTEL_EXPORTER_CA_FILE", caFile)
+		t.Setenv("OTEL_EXPORTER_CERT_FILE", certFile)
+		t.Setenv("OTEL_EXPORTER_KEY_FILE", keyFile)
+		t.Setenv("OTEL_EXPORTER_SERVER_NAME", "collector.internal")
+		t.Setenv("OTEL_EXPORTER_HEADERS", "authorization=Bearer%20x, x-tenant=acme")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+		if !cfg.OtelExporterTLS || cfg.OtelExporterServerName != "collector.internal" {
+			t.Errorf("Unexpected TLS settings: %+v", cfg)
+		}
+		headers, err := cfg.ExporterHeaders()
+		if err != nil {
+			t.Fatalf("ExporterHeaders failed: %v", err)
+		}
+		if headers["authorization"] != "Bearer x" || headers["x-tenant"] != "acme" {
+			t.Errorf("Unexpected exporter headers: %v", headers)
+		}
+	})
+
+	t.Run("Header With Plus", func(t *testing.T) {
+		t.Setenv("OTEL_EXPORTER_HEADERS", "authorization=Basic%20dXNlcjpw+YXNz")
+
+		var cfg BaseConfig
+		if err := LoadCfg(&cfg); err != nil {
+			t.Fatalf("LoadCfg failed: %v", err)
+		}
+		headers, err := cfg.ExporterHeaders()
+		if err != nil {
+			t.Fatalf("ExporterHeaders failed: %v", err)
+		}
+		if headers["authorization"] != "Basic dXNlcjpw+YXNz" {
+			t.Errorf("Expected '+' to be kept, got %q", headers["authorization"])
+		}
+	})
+
+	invalid := []struct {
+		name string
+		env  map[string]string
+	}{
+		{"Missing CA File", map[string]string{
+			"OTEL_EXPORTER_TLS":     "true",
+			"OTEL_EXPORTER_CA_FILE": filepath.Join(dir, "missing.pem"),
+		}},
+		{"CA Path Is Directory", map[string]string{
+			"OTEL_EXPORTER_TLS":     "true",
+			"OTEL_EXPORTER_CA_FILE": dir,
+		}},
+		{"Cert Without Key", map[string]string{
+			"OTEL_EXPORTER_TLS":       "true",
+			"OTEL_EXPORTER_CERT_FILE": certFile,
+		}},
+		{"Certificates Without TLS", map[string]string{
+			"OTEL_EXPORTER_CA_FILE": caFile,
+		}},
+		{"Malformed Headers", map[string]string{
+			"OTEL_EXPORTER_HEADERS": "authorization",
+		}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			var cfg BaseConfig
+			if err := LoadCfg(&cfg); err == nil {
+				t.Errorf("Expected LoadCfg to fail for %s", tt.name)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package observability
+
+import (
+	"crypto/subtle"
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+)
+
+// Paths of the debug endpoints mounted on the metrics server when DEBUG_ENDPOINTS_ENABLED is set
+const (
+	PprofPath     = "/debug/pprof/"
+	ExpvarPath    = "/debug/vars"
+	BuildInfoPath = "/buildinfo"
+)
+
+// pprofProfiles are the runtime/pprof profiles mounted by name under PprofPath
+var pprofProfiles = []string{"allocs", "block", "goroutine", "heap", "mutex", "threadcreate"}
+
+// mountDebugEndpoints adds pprof, expvar and /buildinfo to mux, behind the bearer token when set
+func mountDebugEndpoints(mux *http.ServeMux, cfg BaseConfig) {
+	guard := func(h http.Handler) http.Handler { return RequireBearerToken(cfg.DebugEndpointsToken, h) }
+
+	mux.Handle(PprofPath, guard(PprofHandler()))
+	mux.Handle(ExpvarPath, guard(ExpvarHandler()))
+	mux.Handle(BuildInfoPath, guard(BuildInfoHandler(cfg)))
+}
+
+// RequireBearerToken rejects requests without "Authorization: Bearer <token>" with 401
+// Unauthorized; an empty token lets every request through
+func RequireBearerToken(token string, next http.Handler) http.Handler {
+	if token == "" {
+		return next
+	}
+	expected := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="debug"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// PprofHandler serves the net/http/pprof index, profile, trace, symbol and cmdline endpoints
+// and the named profiles under /debug/pprof/
+func PprofHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(PprofPath, pprof.Index)
+	mux.HandleFunc(PprofPath+"cmdline", pprof.Cmdline)
+	mux.HandleFunc(PprofPath+"profile", pprof.Profile)
+	mux.HandleFunc(PprofPath+"symbol", pprof.Symbol)
+	mux.HandleFunc(PprofPath+"trace", pprof.Trace)
+	for _, name := range pprofProfiles {
+		mux.Handle(PprofPath+name, pprof.Handler(name))
+	}
+	return mux
+}
+
+// ExpvarHandler serves the published expvar variables as JSON
+func ExpvarHandler() http.Handler {
+	return expvar.Handler()
+}
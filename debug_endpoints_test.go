@@ -0,0 +1,144 @@
+package observability
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRequireBearerToken(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) })
+
+	tests := []struct {
+		name          string
+		token         string
+		authorization string
+		wantStatus    int
+	}{
+		{name: "No Token Configured", token: "", authorization: "", wantStatus: http.StatusNoContent},
+		{name: "Valid Token", token: "s3cret", authorization: "Bearer s3cret", wantStatus: http.StatusNoContent},
+		{name: "Missing Header", token: "s3cret", authorization: "", wantStatus: http.StatusUnauthorized},
+		{name: "Wrong Token", token: "s3cret", authorization: "Bearer other", wantStatus: http.StatusUnauthorized},
+		{name: "Wrong Scheme", token: "s3cret", authorization: "Basic s3cret", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/buildinfo", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			RequireBearerToken(tt.token, next).ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+				t.Error("expected a WWW-Authenticate challenge")
+			}
+		})
+	}
+}
+
+func TestPprofHandler(t *testing.T) {
+	handler := PprofHandler()
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "Index", path: PprofPath, wantStatus: http.StatusOK, wantBody: "goroutine"},
+		{name: "Goroutine Text", path: PprofPath + "goroutine?debug=1", wantStatus: http.StatusOK, wantBody: "goroutine profile"},
+		{name: "Heap Binary", path: PprofPath + "heap", wantStatus: http.StatusOK},
+		{name: "CPU Profile", path: PprofPath + "profile?seconds=1", wantStatus: http.StatusOK},
+		{name: "Execution Trace", path: PprofPath + "trace?seconds=0.05", wantStatus: http.StatusOK},
+		{name: "Cmdline", path: PprofPath + "cmdline", wantStatus: http.StatusOK},
+		{name: "Symbol", path: PprofPath + "symbol", wantStatus: http.StatusOK, wantBody: "num_symbols"},
+		{name: "Unknown Profile", path: PprofPath + "nope", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK && w.Body.Len() == 0 {
+				t.Error("expected a non-empty body")
+			}
+			if tt.wantBody != "" && !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("expected body to contain %q", tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestExpvarHandler(t *testing.T) {
+	w := httptest.NewRecorder()
+	ExpvarHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, ExpvarPath, nil))
+
+	var vars map[string]json.RawMessage
+	if err := json.Unmarshal(w.Body.Bytes(), &vars); err != nil {
+		t.Fatalf("expected valid JSON: %v\n%s", err, w.Body.String())
+	}
+	if _, ok := vars["memstats"]; !ok {
+		t.Error("expected memstats to be published")
+	}
+}
+
+func TestInitOtel_DebugEndpoints(t *testing.T) {
+	tests := []struct {
+		name       string
+		enabled    bool
+		token      string
+		auth       string
+		wantStatus int
+	}{
+		{name: "Disabled", enabled: false, wantStatus: http.StatusNotFound},
+		{name: "Enabled", enabled: true, wantStatus: http.StatusOK},
+		{name: "Token Required", enabled: true, token: "s3cret", wantStatus: http.StatusUnauthorized},
+		{name: "Token Provided", enabled: true, token: "s3cret", auth: "Bearer s3cret", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := BaseConfig{
+				ServiceName:           "debug-service",
+				MetricsMode:           "pull",
+				MetricsPath:           "/metrics",
+				DebugEndpointsEnabled: tt.enabled,
+				DebugEndpointsToken:   tt.token,
+			}
+			tel, err := InitOtelWithOptions(cfg, WithoutGlobals(), WithSpanExporter(tracetest.NewInMemoryExporter()))
+			if err != nil {
+				t.Fatalf("InitOtelWithOptions failed: %v", err)
+			}
+			defer func() { _ = tel.Shutdown(context.Background()) }()
+
+			for _, path := range []string{BuildInfoPath, ExpvarPath, PprofPath, PprofPath + "heap"} {
+				req, _ := http.NewRequest(http.MethodGet, "http://"+tel.MetricsAddr()+path, nil)
+				if tt.auth != "" {
+					req.Header.Set("Authorization", tt.auth)
+				}
+				resp, err := http.DefaultClient.Do(req)
+				if err != nil {
+					t.Fatalf("GET %s failed: %v", path, err)
+				}
+				_ = resp.Body.Close()
+				if resp.StatusCode != tt.wantStatus {
+					t.Errorf("GET %s: expected status %d, got %d", path, tt.wantStatus, resp.StatusCode)
+				}
+			}
+		})
+	}
+}
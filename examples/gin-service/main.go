@@ -20,10 +20,6 @@ type Config struct {
 	Port int `env:"PORT" env-default:"8080"`
 }
 
-var (
-logger *observability.Logger
-)
-
 func main() {
 	// 1. Load Config
 	var cfg Config
@@ -32,9 +28,13 @@ func main() {
 	}
 
 	// 2. Init Logger
-	logger = observability.NewLogger(&cfg.BaseConfig)
+	logger := observability.NewLogger(&cfg.BaseConfig)
 	defer logger.Sync()
 
+	// Serve this logger's level on /loglevel (LOG_LEVEL_ENDPOINT_ENABLED=true) and let SIGUSR1/SIGUSR2 adjust it
+	observability.SetDefaultLogger(logger)
+	defer logger.WatchLevelSignals()()
+
 	logger.Info("Starting gin-service", "version", cfg.Version, "port", cfg.Port)
 
 	// 3. Init Otel
@@ -121,6 +121,9 @@ attribute.String("endpoint", "/ping"),
 attribute.String("method", "GET"),
 ))
 
+	// GinLogger stores a logger carrying trace_id, span_id, method, route and request ID
+	observability.LoggerFromGin(c).Info("Ping handled", "latency_ms", ms)
+
 	c.JSON(http.StatusOK, gin.H{
 "message":    "pong",
 "latency_ms": ms,
@@ -141,6 +144,8 @@ func getUserHandler(c *gin.Context) {
 	counter, _ := meter.Int64Counter("gin_user_requests_total")
 	counter.Add(ctx, 1)
 
+	observability.LoggerFromContext(ctx).Info("User fetched", "user_id", userID)
+
 	c.JSON(http.StatusOK, gin.H{
 "user_id": userID,
 "name":    "Test User",
@@ -155,6 +160,7 @@ func panicHandler(c *gin.Context) {
 
 func errorHandler(c *gin.Context) {
 	// Return a client error
+	observability.LoggerFromGin(c).Warn("Rejecting request", "reason", "invalid parameters")
 	c.JSON(http.StatusBadRequest, gin.H{
 "error":   "Bad Request",
 "message": "Invalid parameters provided",
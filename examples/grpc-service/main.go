@@ -28,10 +28,7 @@ type Config struct {
 	HealthPort int `env:"HEALTH_PORT" env-default:"8080"`
 }
 
-var (
-	logger       *observability.Logger
-	requestCount atomic.Int32
-)
+var requestCount atomic.Int32
 
 // HelloServer implements the HelloService
 type HelloServer struct {
@@ -72,6 +69,9 @@ func (s *HelloServer) SayHello(ctx context.Context, req *pb.HelloRequest) (*pb.H
 
 	message := fmt.Sprintf("Hello, %s! (request #%d)", req.Name, count)
 
+	// The interceptors put a logger carrying trace_id, span_id, the RPC and the request ID in ctx
+	observability.LoggerFromContext(ctx).Info("Greeting sent", "name", req.Name, "request_count", count)
+
 	return &pb.HelloResponse{
 		Message:      message,
 		RequestCount: count,
@@ -108,8 +108,10 @@ func (s *HelloServer) SayHelloStream(req *pb.HelloRequest, stream pb.HelloServic
 			Message:      message,
 			RequestCount: count,
 		}); err != nil {
+			observability.LoggerFromContext(ctx).Warn("Stream send failed", "error", err)
 			return err
 		}
+		observability.LoggerFromContext(ctx).Info("Greeting streamed", "name", req.Name, "request_count", count)
 
 		time.Sleep(50 * time.Millisecond)
 	}
@@ -125,9 +127,13 @@ func main() {
 	}
 
 	// 2. Init Logger
-	logger = observability.NewLogger(&cfg.BaseConfig)
+	logger := observability.NewLogger(&cfg.BaseConfig)
 	defer logger.Sync()
 
+	// Serve this logger's level on /loglevel (LOG_LEVEL_ENDPOINT_ENABLED=true) and let SIGUSR1/SIGUSR2 adjust it
+	observability.SetDefaultLogger(logger)
+	defer logger.WatchLevelSignals()()
+
 	logger.Info("Starting grpc-service",
 		"version", cfg.Version,
 		"grpc_port", cfg.Port,
@@ -173,7 +179,7 @@ func main() {
 	}
 
 	// Start HTTP health check endpoint in a goroutine
-	go startHealthServer(cfg.HealthPort)
+	go startHealthServer(logger, cfg.HealthPort)
 
 	logger.Info("gRPC server listening", "addr", lis.Addr())
 	if err := grpcServer.Serve(lis); err != nil {
@@ -182,7 +188,7 @@ func main() {
 }
 
 // startHealthServer starts an HTTP server for health checks and readiness probes
-func startHealthServer(port int) {
+func startHealthServer(logger *observability.Logger, port int) {
 	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		io.WriteString(w, "ok")
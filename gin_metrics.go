@@ -0,0 +1,129 @@
+package observability
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// httpMethodOther is the semantic-convention value for non-standard HTTP methods
+const httpMethodOther = "_OTHER"
+
+// httpServerDurationBuckets are the bucket boundaries (seconds) recommended by the
+// OpenTelemetry HTTP semantic conventions for http.server.request.duration
+var httpServerDurationBuckets = []float64{
+	0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10,
+}
+
+// httpRequestMethod normalizes the request method, mapping unknown methods to "_OTHER"
+// so arbitrary client input cannot inflate metric cardinality
+func httpRequestMethod(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return method
+	default:
+		return httpMethodOther
+	}
+}
+
+// ginServerInstruments holds the HTTP server instruments recorded by GinMetrics
+type ginServerInstruments struct {
+	duration       metric.Float64Histogram
+	activeRequests metric.Int64UpDownCounter
+	requestSize    metric.Int64Histogram
+	responseSize   metric.Int64Histogram
+}
+
+func newGinServerInstruments() *ginServerInstruments {
+	meter := GetMeter("gin-server")
+	inst := &ginServerInstruments{}
+
+	var err error
+	if inst.duration, err = meter.Float64Histogram(
+		"http.server.request.duration",
+		metric.WithDescription("Duration of HTTP server requests"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(httpServerDurationBuckets...),
+	); err != nil {
+		otel.Handle(err)
+	}
+	if inst.activeRequests, err = meter.Int64UpDownCounter(
+		"http.server.active_requests",
+		metric.WithDescription("Number of active HTTP server requests"),
+		metric.WithUnit("{request}"),
+	); err != nil {
+		otel.Handle(err)
+	}
+	if inst.requestSize, err = meter.Int64Histogram(
+		"http.server.request.body.size",
+		metric.WithDescription("Size of HTTP server request bodies"),
+		metric.WithUnit("By"),
+	); err != nil {
+		otel.Handle(err)
+	}
+	if inst.responseSize, err = meter.Int64Histogram(
+		"http.server.response.body.size",
+		metric.WithDescription("Size of HTTP server response bodies"),
+		metric.WithUnit("By"),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	return inst
+}
+
+// GinMetrics middleware records OpenTelemetry HTTP server metrics for requests
+func GinMetrics() gin.HandlerFunc {
+	return GinMetricsWithConfig(nil)
+}
+
+// GinMetricsWithConfig middleware records OpenTelemetry HTTP server metrics for requests with skip configuration
+func GinMetricsWithConfig(cfg *ObservabilityMiddlewareConfig) gin.HandlerFunc {
+	inst := newGinServerInstruments()
+
+	return func(c *gin.Context) {
+		// Check if this path should be skipped
+		if cfg.shouldSkipRoute(c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		ctx := c.Request.Context()
+		method := semconv.HTTPRequestMethodKey.String(httpRequestMethod(c.Request.Method))
+
+		activeAttrs := metric.WithAttributeSet(attribute.NewSet(method))
+		inst.activeRequests.Add(ctx, 1, activeAttrs)
+		defer inst.activeRequests.Add(ctx, -1, activeAttrs)
+
+		// Process request
+		c.Next()
+
+		attrs := []attribute.KeyValue{
+			method,
+			semconv.HTTPResponseStatusCode(c.Writer.Status()),
+		}
+		if route := c.FullPath(); route != "" {
+			attrs = append(attrs, semconv.HTTPRoute(route))
+		}
+		recordAttrs := metric.WithAttributeSet(attribute.NewSet(attrs...))
+
+		inst.duration.Record(ctx, time.Since(start).Seconds(), recordAttrs)
+
+		if size := c.Request.ContentLength; size >= 0 {
+			inst.requestSize.Record(ctx, size, recordAttrs)
+		}
+		// Size() is -1 when nothing has been written yet
+		responseSize := c.Writer.Size()
+		if responseSize < 0 {
+			responseSize = 0
+		}
+		inst.responseSize.Record(ctx, int64(responseSize), recordAttrs)
+	}
+}
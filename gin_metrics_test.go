@@ -0,0 +1,141 @@
+package observability
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestHTTPRequestMethod(t *testing.T) {
+	tests := map[string]string{
+		http.MethodGet:    http.MethodGet,
+		http.MethodDelete: http.MethodDelete,
+		"PURGE":           "_OTHER",
+		"get":             "_OTHER",
+	}
+	for in, want := range tests {
+		if got := httpRequestMethod(in); got != want {
+			t.Errorf("httpRequestMethod(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGinMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reader := setupTestMetrics(t)
+
+	router := gin.New()
+	router.Use(GinMetrics())
+	router.POST("/users/:id", func(c *gin.Context) {
+		c.String(http.StatusCreated, "created")
+	})
+
+	for _, id := range []string{"1", "2"} {
+		req, _ := http.NewRequest(http.MethodPost, "/users/"+id, strings.NewReader("payload"))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d", w.Code)
+		}
+	}
+
+	m, ok := findMetric(t, reader, "http.server.request.duration")
+	if !ok {
+		t.Fatal("expected http.server.request.duration to be recorded")
+	}
+	hist, ok := m.Data.(metricdata.Histogram[float64])
+	if !ok {
+		t.Fatalf("unexpected data type %T", m.Data)
+	}
+	// Both requests share the route template, so they aggregate into one series
+	if len(hist.DataPoints) != 1 {
+		t.Fatalf("expected 1 data point, got %d", len(hist.DataPoints))
+	}
+	dp := hist.DataPoints[0]
+	if dp.Count != 2 {
+		t.Errorf("expected count 2, got %d", dp.Count)
+	}
+	if v, ok := dp.Attributes.Value("http.route"); !ok || v.AsString() != "/users/:id" {
+		t.Errorf("expected http.route=/users/:id, got %v", v)
+	}
+	if v, ok := dp.Attributes.Value("http.request.method"); !ok || v.AsString() != http.MethodPost {
+		t.Errorf("expected http.request.method=POST, got %v", v)
+	}
+	if v, ok := dp.Attributes.Value("http.response.status_code"); !ok || v.AsInt64() != http.StatusCreated {
+		t.Errorf("expected http.response.status_code=201, got %v", v)
+	}
+
+	m, ok = findMetric(t, reader, "http.server.request.body.size")
+	if !ok {
+		t.Fatal("expected http.server.request.body.size to be recorded")
+	}
+	if sizes := m.Data.(metricdata.Histogram[int64]); sizes.DataPoints[0].Sum != int64(2*len("payload")) {
+		t.Errorf("expected request body size sum %d, got %d", 2*len("payload"), sizes.DataPoints[0].Sum)
+	}
+
+	m, ok = findMetric(t, reader, "http.server.response.body.size")
+	if !ok {
+		t.Fatal("expected http.server.response.body.size to be recorded")
+	}
+	if sizes := m.Data.(metricdata.Histogram[int64]); sizes.DataPoints[0].Sum != int64(2*len("created")) {
+		t.Errorf("expected response body size sum %d, got %d", 2*len("created"), sizes.DataPoints[0].Sum)
+	}
+
+	m, ok = findMetric(t, reader, "http.server.active_requests")
+	if !ok {
+		t.Fatal("expected http.server.active_requests to be recorded")
+	}
+	if active := m.Data.(metricdata.Sum[int64]); active.DataPoints[0].Value != 0 {
+		t.Errorf("expected 0 active requests after completion, got %d", active.DataPoints[0].Value)
+	}
+}
+
+func TestGinMetricsWithExcludedPaths(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reader := setupTestMetrics(t)
+
+	router := gin.New()
+	router.Use(GinMetricsWithConfig(&ObservabilityMiddlewareConfig{
+		ExcludedPaths: []string{"/health"},
+	}))
+	router.GET("/health", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/health", nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if _, ok := findMetric(t, reader, "http.server.request.duration"); ok {
+		t.Error("expected no metrics for excluded path")
+	}
+}
+
+func TestGinMetricsRecordsRecoveredPanics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reader := setupTestMetrics(t)
+
+	router := gin.New()
+	for _, mw := range GinMiddleware(NewLogger(nil), "test-service") {
+		router.Use(mw)
+	}
+	router.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/panic", nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	m, ok := findMetric(t, reader, "http.server.request.duration")
+	if !ok {
+		t.Fatal("expected http.server.request.duration to be recorded")
+	}
+	dp := m.Data.(metricdata.Histogram[float64]).DataPoints[0]
+	if v, _ := dp.Attributes.Value(attribute.Key("http.response.status_code")); v.AsInt64() != http.StatusInternalServerError {
+		t.Errorf("expected status 500 to be recorded, got %v", v)
+	}
+}
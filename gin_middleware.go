@@ -2,13 +2,18 @@ package observability
 
 import (
 	"fmt"
+	"net"
 	"net/http"
 	"runtime/debug"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -42,6 +47,20 @@ func (c *ObservabilityMiddlewareConfig) shouldSkipRoute(path string) bool {
 	return false
 }
 
+// ginSpanName returns the span name for a request: "METHOD /route/:template" for
+// matched routes, or just the method for unmatched ones (e.g. 404) so that raw
+// paths never end up in span names. Non-standard methods are named "HTTP".
+func ginSpanName(method, route string) string {
+	method = httpRequestMethod(method)
+	if method == httpMethodOther {
+		method = "HTTP"
+	}
+	if route == "" {
+		return method
+	}
+	return method + " " + route
+}
+
 // GinTracing middleware creates OpenTelemetry spans for HTTP requests
 func GinTracing(serviceName string) gin.HandlerFunc {
 	return GinTracingWithConfig(serviceName, nil)
@@ -62,9 +81,13 @@ func GinTracingWithConfig(serviceName string, cfg *ObservabilityMiddlewareConfig
 		// Extract trace context from incoming headers (W3C Trace Context)
 		ctx := propagator.Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
 
+		// Name the span after the route template to keep cardinality bounded
+		route := c.FullPath()
+
 		// Create a span for this request
-		ctx, span := tracer.Start(ctx, c.Request.Method+" "+c.Request.URL.Path,
+		ctx, span := tracer.Start(ctx, ginSpanName(c.Request.Method, route),
 			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(ginRequestAttributes(c, route)...),
 		)
 		defer span.End()
 
@@ -78,7 +101,88 @@ func GinTracingWithConfig(serviceName string, cfg *ObservabilityMiddlewareConfig
 		}
 
 		c.Next()
+
+		finishGinSpan(span, c)
+	}
+}
+
+// ginRequestAttributes returns the HTTP server semantic convention attributes known
+// when the request starts
+func ginRequestAttributes(c *gin.Context, route string) []attribute.KeyValue {
+	req := c.Request
+
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(httpRequestMethod(req.Method)),
+		semconv.URLScheme(scheme),
+		semconv.URLPath(req.URL.Path),
+		semconv.NetworkProtocolVersion(httpProtocolVersion(req)),
+	}
+	if route != "" {
+		attrs = append(attrs, semconv.HTTPRoute(route))
+	}
+	if host, port := splitHostPort(req.Host); host != "" {
+		attrs = append(attrs, semconv.ServerAddress(host))
+		if port > 0 {
+			attrs = append(attrs, semconv.ServerPort(port))
+		}
+	}
+	if clientIP := c.ClientIP(); clientIP != "" {
+		attrs = append(attrs, semconv.ClientAddress(clientIP))
+	}
+	if userAgent := req.UserAgent(); userAgent != "" {
+		attrs = append(attrs, semconv.UserAgentOriginal(userAgent))
 	}
+	if req.ContentLength > 0 {
+		attrs = append(attrs, semconv.HTTPRequestBodySize(int(req.ContentLength)))
+	}
+
+	return attrs
+}
+
+// finishGinSpan records the response attributes, handler errors and status on the span
+func finishGinSpan(span trace.Span, c *gin.Context) {
+	statusCode := c.Writer.Status()
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(statusCode))
+	if size := c.Writer.Size(); size > 0 {
+		span.SetAttributes(semconv.HTTPResponseBodySize(size))
+	}
+
+	// Errors attached by handlers via c.Error() become exception events
+	for _, ginErr := range c.Errors {
+		span.RecordError(ginErr.Err)
+	}
+
+	// Only 5xx responses are server-side errors; 4xx are left unset per semantic conventions
+	if statusCode >= 500 {
+		span.SetStatus(codes.Error, http.StatusText(statusCode))
+	}
+}
+
+// httpProtocolVersion returns the network.protocol.version value ("1.1", "2", ...)
+func httpProtocolVersion(req *http.Request) string {
+	if req.ProtoMajor >= 2 && req.ProtoMinor == 0 {
+		return strconv.Itoa(req.ProtoMajor)
+	}
+	return fmt.Sprintf("%d.%d", req.ProtoMajor, req.ProtoMinor)
+}
+
+// splitHostPort splits a "host[:port]" string, returning port 0 when absent or invalid
+func splitHostPort(hostport string) (string, int) {
+	host, portStr, err := net.SplitHostPort(hostport)
+	if err != nil {
+		return hostport, 0
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return host, 0
+	}
+	return host, port
 }
 
 // GinLogger middleware logs HTTP requests with OpenTelemetry trace context
@@ -99,11 +203,21 @@ func GinLoggerWithConfig(logger *Logger, cfg *ObservabilityMiddlewareConfig) gin
 		path := c.Request.URL.Path
 		query := c.Request.URL.RawQuery
 
-		// Extract trace context if available
-		span := trace.SpanFromContext(c.Request.Context())
-		spanContext := span.SpanContext()
-		traceID := spanContext.TraceID().String()
-		spanID := spanContext.SpanID().String()
+		// Capture the request context (with the tracing span) before handlers replace it
+		ctx := c.Request.Context()
+
+		// Reuse the caller's request ID when it is safe to log, else generate one, and echo it back
+		requestID := requestIDOrNew(c.GetHeader(RequestIDHeader))
+		c.Header(RequestIDHeader, requestID)
+
+		// Expose a request-scoped logger to handlers (see LoggerFromGin / LoggerFromContext)
+		reqLogger := logger.WithContext(ctx).child(
+			"method", c.Request.Method,
+			"route", c.FullPath(),
+			"request_id", requestID,
+		)
+		c.Set(ginLoggerKey, reqLogger)
+		c.Request = c.Request.WithContext(ContextWithLogger(ctx, reqLogger))
 
 		// Process request
 		c.Next()
@@ -119,19 +233,13 @@ func GinLoggerWithConfig(logger *Logger, cfg *ObservabilityMiddlewareConfig) gin
 		fields := []interface{}{
 			"status", statusCode,
 			"method", method,
+			"route", c.FullPath(),
 			"path", path,
 			"query", query,
 			"ip", clientIP,
 			"latency_ms", latency.Milliseconds(),
 			"user_agent", c.Request.UserAgent(),
-		}
-
-		// Add trace context if present
-		if traceID != "" && traceID != "00000000000000000000000000000000" {
-			fields = append(fields, "trace_id", traceID)
-		}
-		if spanID != "" && spanID != "0000000000000000" {
-			fields = append(fields, "span_id", spanID)
+			"request_id", requestID,
 		}
 
 		// Add error message if present
@@ -139,14 +247,14 @@ func GinLoggerWithConfig(logger *Logger, cfg *ObservabilityMiddlewareConfig) gin
 			fields = append(fields, "error", errorMessage)
 		}
 
-		// Log based on status code
+		// Log based on status code (trace context is attached from ctx)
 		switch {
 		case statusCode >= 500:
-			logger.Error("HTTP Server Error", fields...)
+			logger.ErrorCtx(ctx, "HTTP Server Error", fields...)
 		case statusCode >= 400:
-			logger.Warn("HTTP Client Error", fields...)
+			logger.WarnCtx(ctx, "HTTP Client Error", fields...)
 		default:
-			logger.Info("HTTP Request", fields...)
+			logger.InfoCtx(ctx, "HTTP Request", fields...)
 		}
 	}
 }
@@ -176,17 +284,22 @@ func GinRecoveryWithConfig(logger *Logger, cfg *ObservabilityMiddlewareConfig) g
 				}
 
 				// Extract trace context
-				span := trace.SpanFromContext(c.Request.Context())
+				ctx := c.Request.Context()
+				span := trace.SpanFromContext(ctx)
 				spanContext := span.SpanContext()
-				traceID := spanContext.TraceID().String()
 
 				// Get stack trace
 				stack := string(debug.Stack())
 
+				// Record the panic as an exception on the request span
+				span.RecordError(fmt.Errorf("%v", err),
+					trace.WithAttributes(semconv.ExceptionStacktrace(stack)),
+				)
+				span.SetStatus(codes.Error, "panic recovered")
+
 				// Log the panic with full context
-				logger.Error("Panic recovered",
+				logger.ErrorCtx(ctx, "Panic recovered",
 					"error", fmt.Sprintf("%v", err),
-					"trace_id", traceID,
 					"path", c.Request.URL.Path,
 					"method", c.Request.Method,
 					"stack", stack,
@@ -200,8 +313,8 @@ func GinRecoveryWithConfig(logger *Logger, cfg *ObservabilityMiddlewareConfig) g
 				}
 
 				// Include trace_id if available (for debugging)
-				if traceID != "" && traceID != "00000000000000000000000000000000" {
-					errorResp.TraceID = traceID
+				if spanContext.HasTraceID() {
+					errorResp.TraceID = spanContext.TraceID().String()
 				}
 
 				// Return 500 error
@@ -213,17 +326,18 @@ func GinRecoveryWithConfig(logger *Logger, cfg *ObservabilityMiddlewareConfig) g
 	}
 }
 
-// GinMiddleware combines tracing, recovery, and logging middleware
+// GinMiddleware combines tracing, metrics, recovery, and logging middleware
 // Usage: router.Use(observability.GinMiddleware(logger, "service-name")...)
 func GinMiddleware(logger *Logger, serviceName string) []gin.HandlerFunc {
 	return GinMiddlewareWithConfig(logger, serviceName, nil)
 }
 
-// GinMiddlewareWithConfig combines tracing, recovery, and logging middleware with skip configuration
+// GinMiddlewareWithConfig combines tracing, metrics, recovery, and logging middleware with skip configuration
 // Usage: router.Use(observability.GinMiddlewareWithConfig(logger, "service-name", cfg)...)
 func GinMiddlewareWithConfig(logger *Logger, serviceName string, cfg *ObservabilityMiddlewareConfig) []gin.HandlerFunc {
 	return []gin.HandlerFunc{
 		GinTracingWithConfig(serviceName, cfg),
+		GinMetricsWithConfig(cfg),
 		GinRecoveryWithConfig(logger, cfg),
 		GinLoggerWithConfig(logger, cfg),
 	}
@@ -2,6 +2,7 @@ package observability
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -9,6 +10,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -506,4 +509,186 @@ func TestGinTracing(t *testing.T) {
 	// In test environments without a tracer provider the header may be empty.
 	traceID := w.Header().Get("X-Trace-ID")
 	t.Logf("X-Trace-ID header: %s", traceID)
-}
\ No newline at end of file
+}
+
+func TestGinSpanName(t *testing.T) {
+	tests := []struct {
+		method string
+		route  string
+		want   string
+	}{
+		{http.MethodGet, "/users/:id", "GET /users/:id"},
+		{http.MethodPost, "", "POST"},
+		{"PURGE", "/cache", "HTTP /cache"},
+	}
+	for _, tt := range tests {
+		if got := ginSpanName(tt.method, tt.route); got != tt.want {
+			t.Errorf("ginSpanName(%q, %q) = %q, want %q", tt.method, tt.route, got, tt.want)
+		}
+	}
+}
+
+func TestGinTracingUsesRouteTemplate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := setupTestTracing(t)
+
+	router := gin.New()
+	router.Use(GinTracing("test-service"))
+	router.GET("/users/:id", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for _, path := range []string{"/users/123", "/users/456", "/unknown"} {
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+
+	for i, want := range []string{"/users/123", "/users/456"} {
+		span := spans[i]
+		if span.Name() != "GET /users/:id" {
+			t.Errorf("expected span name 'GET /users/:id', got %q", span.Name())
+		}
+		if v, ok := spanAttribute(span, "http.route"); !ok || v.AsString() != "/users/:id" {
+			t.Errorf("expected http.route=/users/:id, got %v", v)
+		}
+		if v, ok := spanAttribute(span, "url.path"); !ok || v.AsString() != want {
+			t.Errorf("expected url.path=%s, got %v", want, v)
+		}
+	}
+
+	// Unmatched routes get a stable name without the raw path
+	notFound := spans[2]
+	if notFound.Name() != "GET" {
+		t.Errorf("expected fallback span name 'GET', got %q", notFound.Name())
+	}
+	if _, ok := spanAttribute(notFound, "http.route"); ok {
+		t.Error("expected no http.route attribute for unmatched route")
+	}
+	if v, _ := spanAttribute(notFound, "url.path"); v.AsString() != "/unknown" {
+		t.Errorf("expected url.path=/unknown, got %v", v)
+	}
+}
+
+func TestGinTracingSemconvAttributes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := setupTestTracing(t)
+
+	router := gin.New()
+	router.Use(GinTracing("test-service"))
+	router.POST("/orders", func(c *gin.Context) {
+		c.String(http.StatusAccepted, "queued")
+	})
+
+	req, _ := http.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"id":1}`))
+	req.Host = "api.example.com:8443"
+	req.Header.Set("User-Agent", "test-agent/1.0")
+	req.RemoteAddr = "10.0.0.7:51000"
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+
+	expectString := map[string]string{
+		"http.request.method":      "POST",
+		"url.scheme":               "http",
+		"url.path":                 "/orders",
+		"http.route":               "/orders",
+		"server.address":           "api.example.com",
+		"client.address":           "10.0.0.7",
+		"user_agent.original":      "test-agent/1.0",
+		"network.protocol.version": "1.1",
+	}
+	for key, want := range expectString {
+		if v, ok := spanAttribute(span, attribute.Key(key)); !ok || v.AsString() != want {
+			t.Errorf("expected %s=%q, got %v", key, want, v)
+		}
+	}
+
+	expectInt := map[string]int64{
+		"server.port":               8443,
+		"http.response.status_code": http.StatusAccepted,
+		"http.request.body.size":    int64(len(`{"id":1}`)),
+		"http.response.body.size":   int64(len("queued")),
+	}
+	for key, want := range expectInt {
+		if v, ok := spanAttribute(span, attribute.Key(key)); !ok || v.AsInt64() != want {
+			t.Errorf("expected %s=%d, got %v", key, want, v)
+		}
+	}
+
+	if span.Status().Code == codes.Error {
+		t.Error("expected non-error span status for 202 response")
+	}
+}
+
+func TestGinTracingStatusAndErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := setupTestTracing(t)
+
+	router := gin.New()
+	for _, mw := range GinMiddleware(NewLogger(nil), "test-service") {
+		router.Use(mw)
+	}
+	router.GET("/bad-request", func(c *gin.Context) {
+		_ = c.Error(errors.New("validation failed"))
+		c.Status(http.StatusBadRequest)
+	})
+	router.GET("/unavailable", func(c *gin.Context) {
+		c.Status(http.StatusServiceUnavailable)
+	})
+	router.GET("/panic", func(c *gin.Context) {
+		panic("kaboom")
+	})
+
+	tests := []struct {
+		path          string
+		expectError   bool
+		expectedEvent string
+	}{
+		{path: "/bad-request", expectError: false, expectedEvent: "validation failed"},
+		{path: "/unavailable", expectError: true},
+		{path: "/panic", expectError: true, expectedEvent: "kaboom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			recorder.Reset()
+
+			req, _ := http.NewRequest(http.MethodGet, tt.path, nil)
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("expected 1 span, got %d", len(spans))
+			}
+			span := spans[0]
+
+			if isError := span.Status().Code == codes.Error; isError != tt.expectError {
+				t.Errorf("expected error status %v, got %v", tt.expectError, isError)
+			}
+
+			if tt.expectedEvent == "" {
+				return
+			}
+			found := false
+			for _, event := range span.Events() {
+				for _, kv := range event.Attributes {
+					if kv.Key == "exception.message" && kv.Value.AsString() == tt.expectedEvent {
+						found = true
+					}
+				}
+			}
+			if !found {
+				t.Errorf("expected exception event %q, got %+v", tt.expectedEvent, span.Events())
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// grpcClientObserver holds the tracer, histogram and logger shared by client interceptors
+type grpcClientObserver struct {
+	logger   *Logger
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+}
+
+func newGrpcClientObserver(logger *Logger) *grpcClientObserver {
+	duration, err := GetMeter("grpc-client").Float64Histogram(
+		"rpc.client.duration",
+		metric.WithDescription("Measures the duration of outbound gRPC calls"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return &grpcClientObserver{
+		logger:   logger,
+		tracer:   otel.Tracer("grpc-client"),
+		duration: duration,
+	}
+}
+
+// start creates a client span and injects its context into the outgoing metadata
+func (o *grpcClientObserver) start(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	ctx, span := o.tracer.Start(ctx, grpcSpanName(fullMethod),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(grpcSpanAttributes(fullMethod)...),
+	)
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+
+	return metadata.NewOutgoingContext(ctx, md), span
+}
+
+// finish ends the client span, records the call duration and logs the outcome
+func (o *grpcClientObserver) finish(ctx context.Context, span trace.Span, fullMethod string, start time.Time, err error, extra ...interface{}) {
+	latency := time.Since(start)
+	grpcStatus := status.Code(err)
+
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(grpcStatus)))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, status.Convert(err).Message())
+	}
+	span.End()
+
+	attrs := append(grpcSpanAttributes(fullMethod), grpcCodeKey.String(grpcStatus.String()))
+	o.duration.Record(ctx, float64(latency)/float64(time.Millisecond),
+		metric.WithAttributeSet(attribute.NewSet(attrs...)),
+	)
+
+	if o.logger == nil {
+		return
+	}
+
+	// Build log fields
+	fields := []interface{}{
+		"method", fullMethod,
+		"grpc_code", grpcStatus.String(),
+		"latency_ms", latency.Milliseconds(),
+	}
+	fields = append(fields, extra...)
+
+	// Add error if present
+	if err != nil {
+		fields = append(fields, "error", err.Error())
+	}
+
+	// Log based on gRPC status code (trace context is attached from ctx)
+	switch grpcStatus {
+	case codes.OK:
+		o.logger.InfoCtx(ctx, "gRPC Outgoing Request", fields...)
+	case codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists,
+		codes.PermissionDenied, codes.Unauthenticated, codes.FailedPrecondition,
+		codes.OutOfRange:
+		o.logger.WarnCtx(ctx, "gRPC Outgoing Client Error", fields...)
+	default:
+		o.logger.ErrorCtx(ctx, "gRPC Outgoing Server Error", fields...)
+	}
+}
+
+// GrpcUnaryClientInterceptor traces, logs and measures outgoing gRPC unary calls,
+// propagating W3C trace context through the outgoing metadata
+func GrpcUnaryClientInterceptor(logger *Logger) grpc.UnaryClientInterceptor {
+	observer := newGrpcClientObserver(logger)
+
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		start := time.Now()
+		ctx, span := observer.start(ctx, method)
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		observer.finish(ctx, span, method, start, err)
+		return err
+	}
+}
+
+// GrpcStreamClientInterceptor traces, logs and measures outgoing gRPC streaming calls,
+// propagating W3C trace context through the outgoing metadata
+func GrpcStreamClientInterceptor(logger *Logger) grpc.StreamClientInterceptor {
+	observer := newGrpcClientObserver(logger)
+
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		start := time.Now()
+		ctx, span := observer.start(ctx, method)
+		extra := []interface{}{
+			"is_client_stream", desc.ClientStreams,
+			"is_server_stream", desc.ServerStreams,
+		}
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			observer.finish(ctx, span, method, start, err, extra...)
+			return nil, err
+		}
+
+		observed := &observedClientStream{
+			ClientStream: stream,
+			desc:         desc,
+			finish: func(err error) {
+				observer.finish(ctx, span, method, start, err, extra...)
+			},
+		}
+		// Like otelgrpc, finish streams the caller cancels or abandons through ctx
+		observed.stopCtxFinish = context.AfterFunc(ctx, func() {
+			observed.finished.Do(func() { observed.finish(status.FromContextError(ctx.Err()).Err()) })
+		})
+		return observed, nil
+	}
+}
+
+// observedClientStream wraps grpc.ClientStream to finish the call once the stream completes or
+// its context is done
+type observedClientStream struct {
+	grpc.ClientStream
+	desc     *grpc.StreamDesc
+	finish   func(error)
+	finished sync.Once
+	// stopCtxFinish unregisters the finish on ctx.Done() once the stream completes
+	stopCtxFinish func() bool
+}
+
+func (s *observedClientStream) done(err error) {
+	s.finished.Do(func() {
+		s.stopCtxFinish()
+		s.finish(err)
+	})
+}
+
+// RecvMsg finishes the call on io.EOF, on error, or after the single response of a non-server-streaming call
+func (s *observedClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case errors.Is(err, io.EOF):
+		s.done(nil)
+	case err != nil:
+		s.done(err)
+	case !s.desc.ServerStreams:
+		s.done(nil)
+	}
+	return err
+}
+
+// SendMsg finishes the call if sending fails
+func (s *observedClientStream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	if err != nil && !errors.Is(err, io.EOF) {
+		s.done(err)
+	}
+	return err
+}
+
+// Header finishes the call if reading the header fails
+func (s *observedClientStream) Header() (metadata.MD, error) {
+	md, err := s.ClientStream.Header()
+	if err != nil {
+		s.done(err)
+	}
+	return md, err
+}
+
+// GrpcClientInterceptors returns dial options installing the unary and stream client interceptors
+// Usage: grpc.NewClient(target, append(opts, observability.GrpcClientInterceptors(logger)...)...)
+func GrpcClientInterceptors(logger *Logger) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(GrpcUnaryClientInterceptor(logger)),
+		grpc.WithChainStreamInterceptor(GrpcStreamClientInterceptor(logger)),
+	}
+}
@@ -0,0 +1,274 @@
+package observability
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// setupTestMetrics installs an in-memory meter provider as global and returns its reader
+func setupTestMetrics(t *testing.T) *sdkmetric.ManualReader {
+	t.Helper()
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	otel.SetMeterProvider(mp)
+	t.Cleanup(func() { _ = mp.Shutdown(context.Background()) })
+
+	return reader
+}
+
+// findMetric collects the reader and returns the metric with the given name
+func findMetric(t *testing.T, reader *sdkmetric.ManualReader, name string) (metricdata.Metrics, bool) {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return m, true
+			}
+		}
+	}
+	return metricdata.Metrics{}, false
+}
+
+// mockClientStream is a minimal grpc.ClientStream returning a scripted sequence of RecvMsg errors
+type mockClientStream struct {
+	grpc.ClientStream
+	recvErrs []error
+}
+
+func (m *mockClientStream) RecvMsg(msg interface{}) error {
+	if len(m.recvErrs) == 0 {
+		return io.EOF
+	}
+	err := m.recvErrs[0]
+	m.recvErrs = m.recvErrs[1:]
+	return err
+}
+
+func (m *mockClientStream) SendMsg(msg interface{}) error { return nil }
+
+func (m *mockClientStream) Header() (metadata.MD, error) { return metadata.MD{}, nil }
+
+func TestGrpcUnaryClientInterceptor(t *testing.T) {
+	recorder := setupTestTracing(t)
+	reader := setupTestMetrics(t)
+	logger := NewLogger(&BaseConfig{ServiceName: "test-grpc-client", LogLevel: "info"})
+
+	interceptor := GrpcUnaryClientInterceptor(logger)
+
+	var outgoing metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		outgoing, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "abc")
+	if err := interceptor(ctx, "/test.Service/TestMethod", &mockRequest{}, &mockResponse{}, nil, invoker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.SpanKind() != trace.SpanKindClient {
+		t.Errorf("expected client span kind, got %v", span.SpanKind())
+	}
+	if span.Name() != "test.Service/TestMethod" {
+		t.Errorf("expected span name 'test.Service/TestMethod', got %q", span.Name())
+	}
+
+	traceparent := outgoing.Get("traceparent")
+	if len(traceparent) != 1 {
+		t.Fatalf("expected traceparent in outgoing metadata, got %v", outgoing)
+	}
+	if want := "00-" + span.SpanContext().TraceID().String() + "-" + span.SpanContext().SpanID().String() + "-01"; traceparent[0] != want {
+		t.Errorf("expected traceparent %q, got %q", want, traceparent[0])
+	}
+	if got := outgoing.Get("x-request-id"); len(got) != 1 || got[0] != "abc" {
+		t.Errorf("expected existing outgoing metadata to be preserved, got %v", got)
+	}
+
+	m, ok := findMetric(t, reader, "rpc.client.duration")
+	if !ok {
+		t.Fatal("expected rpc.client.duration metric to be recorded")
+	}
+	hist, ok := m.Data.(metricdata.Histogram[float64])
+	if !ok || len(hist.DataPoints) != 1 || hist.DataPoints[0].Count != 1 {
+		t.Errorf("expected one histogram data point with count 1, got %+v", m.Data)
+	}
+}
+
+func TestGrpcUnaryClientInterceptor_Error(t *testing.T) {
+	recorder := setupTestTracing(t)
+	reader := setupTestMetrics(t)
+	logger := NewLogger(&BaseConfig{ServiceName: "test-grpc-client", LogLevel: "info"})
+
+	interceptor := GrpcUnaryClientInterceptor(logger)
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return status.Error(codes.NotFound, "missing")
+	}
+
+	err := interceptor(context.Background(), "/test.Service/TestMethod", &mockRequest{}, &mockResponse{}, nil, invoker)
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Status().Code != otelcodes.Error {
+		t.Errorf("expected error span status on client side, got %v", spans[0].Status().Code)
+	}
+	if v, _ := spanAttribute(spans[0], "rpc.grpc.status_code"); v.AsInt64() != int64(codes.NotFound) {
+		t.Errorf("expected rpc.grpc.status_code=%d, got %v", codes.NotFound, v)
+	}
+
+	// Same dimension as rpc.server.duration so client and server histograms can be joined
+	m, ok := findMetric(t, reader, "rpc.client.duration")
+	if !ok {
+		t.Fatal("expected rpc.client.duration metric to be recorded")
+	}
+	hist := m.Data.(metricdata.Histogram[float64])
+	if code, _ := hist.DataPoints[0].Attributes.Value("grpc_code"); code.AsString() != "NotFound" {
+		t.Errorf("expected grpc_code=NotFound, got %v", code.Emit())
+	}
+}
+
+func TestGrpcStreamClientInterceptor(t *testing.T) {
+	recorder := setupTestTracing(t)
+	setupTestMetrics(t)
+	logger := NewLogger(&BaseConfig{ServiceName: "test-grpc-client", LogLevel: "info"})
+
+	interceptor := GrpcStreamClientInterceptor(logger)
+	desc := &grpc.StreamDesc{StreamName: "StreamMethod", ServerStreams: true}
+
+	var outgoing metadata.MD
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		outgoing, _ = metadata.FromOutgoingContext(ctx)
+		return &mockClientStream{recvErrs: []error{nil, nil}}, nil
+	}
+
+	stream, err := interceptor(context.Background(), desc, nil, "/test.Service/StreamMethod", streamer)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(outgoing.Get("traceparent")) != 1 {
+		t.Errorf("expected traceparent in outgoing metadata, got %v", outgoing)
+	}
+
+	// Two messages, then io.EOF ends the call
+	for i := 0; i < 2; i++ {
+		if err := stream.RecvMsg(&mockResponse{}); err != nil {
+			t.Fatalf("unexpected RecvMsg error: %v", err)
+		}
+		if len(recorder.Ended()) != 0 {
+			t.Fatal("span ended before the stream completed")
+		}
+	}
+	if err := stream.RecvMsg(&mockResponse{}); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span after stream completion, got %d", len(spans))
+	}
+	if spans[0].Status().Code == otelcodes.Error {
+		t.Error("expected non-error span status for completed stream")
+	}
+}
+
+func TestGrpcStreamClientInterceptor_ContextCanceled(t *testing.T) {
+	recorder := setupTestTracing(t)
+	reader := setupTestMetrics(t)
+
+	interceptor := GrpcStreamClientInterceptor(NewLogger(nil))
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &mockClientStream{recvErrs: []error{nil}}, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := interceptor(ctx, &grpc.StreamDesc{ServerStreams: true}, nil, "/test.Service/StreamMethod", streamer)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := stream.RecvMsg(&mockResponse{}); err != nil {
+		t.Fatalf("unexpected RecvMsg error: %v", err)
+	}
+
+	// The caller abandons the stream and cancels its context
+	cancel()
+	deadline := time.Now().Add(time.Second)
+	for len(recorder.Ended()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].Status().Code != otelcodes.Error {
+		t.Fatalf("expected 1 error span after cancellation, got %d", len(spans))
+	}
+	m, ok := findMetric(t, reader, "rpc.client.duration")
+	if !ok {
+		t.Fatal("rpc.client.duration not found")
+	}
+	dps := m.Data.(metricdata.Histogram[float64]).DataPoints
+	if len(dps) != 1 {
+		t.Fatalf("expected 1 data point, got %d", len(dps))
+	}
+	if code, _ := dps[0].Attributes.Value(grpcCodeKey); code.AsString() != codes.Canceled.String() {
+		t.Errorf("expected grpc_code=Canceled, got %q", code.AsString())
+	}
+
+	// Reading after the cancellation does not finish the call twice
+	_ = stream.RecvMsg(&mockResponse{})
+	if len(recorder.Ended()) != 1 {
+		t.Errorf("expected the span to end once, got %d", len(recorder.Ended()))
+	}
+}
+
+func TestGrpcStreamClientInterceptor_StreamerError(t *testing.T) {
+	recorder := setupTestTracing(t)
+	setupTestMetrics(t)
+
+	interceptor := GrpcStreamClientInterceptor(NewLogger(nil))
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return nil, status.Error(codes.Unavailable, "connection refused")
+	}
+
+	_, err := interceptor(context.Background(), &grpc.StreamDesc{}, nil, "/test.Service/StreamMethod", streamer)
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable, got %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].Status().Code != otelcodes.Error {
+		t.Errorf("expected 1 error span, got %d", len(spans))
+	}
+}
+
+func TestGrpcClientInterceptors(t *testing.T) {
+	opts := GrpcClientInterceptors(NewLogger(nil))
+	if len(opts) != 2 {
+		t.Errorf("Expected 2 dial options, got %d", len(opts))
+	}
+}
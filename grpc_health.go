@@ -0,0 +1,102 @@
+package observability
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// defaultGrpcHealthWatchInterval is how often Watch re-runs the checks
+const defaultGrpcHealthWatchInterval = 5 * time.Second
+
+// GrpcHealthServer implements the grpc.health.v1 Health service on top of a HealthRegistry.
+// The empty service name reports overall readiness; any other name reports the check
+// registered under it.
+type GrpcHealthServer struct {
+	healthpb.UnimplementedHealthServer
+	registry      *HealthRegistry
+	watchInterval time.Duration
+}
+
+// NewGrpcHealthServer creates a health service backed by registry. Register it with
+// healthpb.RegisterHealthServer(server, NewGrpcHealthServer(tel.Health())). A nil registry is
+// replaced by an empty one, which reports SERVING.
+func NewGrpcHealthServer(registry *HealthRegistry) *GrpcHealthServer {
+	if registry == nil {
+		registry = NewHealthRegistry()
+	}
+	return &GrpcHealthServer{registry: registry, watchInterval: defaultGrpcHealthWatchInterval}
+}
+
+// Check implements healthpb.HealthServer
+func (s *GrpcHealthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	servingStatus, ok := s.status(ctx, req.GetService())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+	}
+	return &healthpb.HealthCheckResponse{Status: servingStatus}, nil
+}
+
+// List implements healthpb.HealthServer, reporting overall readiness under "" and every check
+func (s *GrpcHealthServer) List(ctx context.Context, _ *healthpb.HealthListRequest) (*healthpb.HealthListResponse, error) {
+	report := s.registry.Readiness(ctx)
+	statuses := map[string]*healthpb.HealthCheckResponse{
+		"": {Status: servingStatus(report.Status != HealthStatusFail)},
+	}
+	for _, result := range report.Checks {
+		statuses[result.Name] = &healthpb.HealthCheckResponse{Status: servingStatus(result.Status == HealthStatusPass)}
+	}
+	return &healthpb.HealthListResponse{Statuses: statuses}, nil
+}
+
+// Watch implements healthpb.HealthServer. It sends the current status, then every change
+// detected by re-running the checks; unknown services report SERVICE_UNKNOWN.
+func (s *GrpcHealthServer) Watch(req *healthpb.HealthCheckRequest, stream grpc.ServerStreamingServer[healthpb.HealthCheckResponse]) error {
+	ctx := stream.Context()
+	ticker := time.NewTicker(s.watchInterval)
+	defer ticker.Stop()
+
+	last := healthpb.HealthCheckResponse_ServingStatus(-1)
+	for {
+		current, ok := s.status(ctx, req.GetService())
+		if !ok {
+			current = healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		}
+		if current != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: current}); err != nil {
+				return err
+			}
+			last = current
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// status returns the serving status of service; ok is false for unknown services
+func (s *GrpcHealthServer) status(ctx context.Context, service string) (healthpb.HealthCheckResponse_ServingStatus, bool) {
+	if service == "" {
+		return servingStatus(s.registry.Readiness(ctx).Status != HealthStatusFail), true
+	}
+	result, ok := s.registry.CheckNamed(ctx, service)
+	if !ok {
+		return healthpb.HealthCheckResponse_SERVICE_UNKNOWN, false
+	}
+	return servingStatus(result.Status == HealthStatusPass), true
+}
+
+// servingStatus maps a health outcome onto the gRPC serving status
+func servingStatus(healthy bool) healthpb.HealthCheckResponse_ServingStatus {
+	if healthy {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
@@ -0,0 +1,137 @@
+package observability
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// mockHealthWatchStream is a minimal Watch stream collecting the sent statuses
+type mockHealthWatchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan healthpb.HealthCheckResponse_ServingStatus
+}
+
+func (s *mockHealthWatchStream) Context() context.Context { return s.ctx }
+
+func (s *mockHealthWatchStream) Send(resp *healthpb.HealthCheckResponse) error {
+	s.sent <- resp.GetStatus()
+	return nil
+}
+
+func TestGrpcHealthServer_Check(t *testing.T) {
+	registry := NewHealthRegistry()
+	_ = registry.Register("db", healthyCheck)
+	_ = registry.Register("cache", failingCheck, NonCritical())
+	server := NewGrpcHealthServer(registry)
+
+	tests := []struct {
+		name     string
+		service  string
+		want     healthpb.HealthCheckResponse_ServingStatus
+		wantCode codes.Code
+	}{
+		{name: "Overall", service: "", want: healthpb.HealthCheckResponse_SERVING},
+		{name: "Healthy Check", service: "db", want: healthpb.HealthCheckResponse_SERVING},
+		{name: "Failing Check", service: "cache", want: healthpb.HealthCheckResponse_NOT_SERVING},
+		{name: "Unknown Service", service: "queue", wantCode: codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: tt.service})
+			if tt.wantCode != codes.OK {
+				if status.Code(err) != tt.wantCode {
+					t.Errorf("expected code %s, got %v", tt.wantCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Check failed: %v", err)
+			}
+			if resp.GetStatus() != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, resp.GetStatus())
+			}
+		})
+	}
+}
+
+func TestGrpcHealthServer_NilRegistry(t *testing.T) {
+	resp, err := NewGrpcHealthServer(nil).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("expected an empty registry to report SERVING, got %s", resp.GetStatus())
+	}
+}
+
+func TestGrpcHealthServer_List(t *testing.T) {
+	registry := NewHealthRegistry()
+	_ = registry.Register("db", failingCheck)
+	server := NewGrpcHealthServer(registry)
+
+	resp, err := server.List(context.Background(), &healthpb.HealthListRequest{})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	statuses := resp.GetStatuses()
+	if len(statuses) != 2 {
+		t.Fatalf("expected overall and db statuses, got %v", statuses)
+	}
+	if statuses[""].GetStatus() != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("expected overall NOT_SERVING, got %s", statuses[""].GetStatus())
+	}
+	if statuses["db"].GetStatus() != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("expected db NOT_SERVING, got %s", statuses["db"].GetStatus())
+	}
+}
+
+func TestGrpcHealthServer_Watch(t *testing.T) {
+	var healthy atomic.Bool
+	registry := NewHealthRegistry()
+	_ = registry.Register("db", func(context.Context) error {
+		if healthy.Load() {
+			return nil
+		}
+		return failingCheck(context.Background())
+	})
+	server := NewGrpcHealthServer(registry)
+	server.watchInterval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &mockHealthWatchStream{ctx: ctx, sent: make(chan healthpb.HealthCheckResponse_ServingStatus, 10)}
+	done := make(chan error, 1)
+	go func() { done <- server.Watch(&healthpb.HealthCheckRequest{Service: "db"}, stream) }()
+
+	next := func() healthpb.HealthCheckResponse_ServingStatus {
+		t.Helper()
+		select {
+		case s := <-stream.sent:
+			return s
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for a status")
+			return 0
+		}
+	}
+
+	if got := next(); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("expected NOT_SERVING first, got %s", got)
+	}
+	healthy.Store(true)
+	if got := next(); got != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("expected SERVING after recovery, got %s", got)
+	}
+
+	cancel()
+	if err := <-done; status.Code(err) != codes.Canceled {
+		t.Errorf("expected Canceled when the stream ends, got %v", err)
+	}
+}
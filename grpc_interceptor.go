@@ -13,6 +13,28 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// grpcRequestID returns the request ID from incoming metadata, generating one if absent or
+// unsafe to log
+func grpcRequestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(RequestIDHeader); len(values) > 0 {
+			return requestIDOrNew(values[0])
+		}
+	}
+	return newRequestID()
+}
+
+// grpcRequestLogger returns a child logger carrying trace context, the RPC and the request ID
+func grpcRequestLogger(ctx context.Context, logger *Logger, fullMethod, requestID string) *Logger {
+	service, method := splitGrpcMethod(fullMethod)
+	return logger.WithContext(ctx).child(
+		"method", fullMethod,
+		"rpc_service", service,
+		"rpc_method", method,
+		"request_id", requestID,
+	)
+}
+
 // GrpcUnaryServerInterceptor logs gRPC unary requests with OpenTelemetry trace context
 func GrpcUnaryServerInterceptor(logger *Logger) grpc.UnaryServerInterceptor {
 	return func(
@@ -22,12 +44,10 @@ func GrpcUnaryServerInterceptor(logger *Logger) grpc.UnaryServerInterceptor {
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
 		start := time.Now()
+		requestID := grpcRequestID(ctx)
 
-		// Extract trace context if available
-		span := trace.SpanFromContext(ctx)
-		spanContext := span.SpanContext()
-		traceID := spanContext.TraceID().String()
-		spanID := spanContext.SpanID().String()
+		// Expose a request-scoped logger to the handler (see LoggerFromContext)
+		ctx = ContextWithLogger(ctx, grpcRequestLogger(ctx, logger, info.FullMethod, requestID))
 
 		// Call the handler
 		resp, err := handler(ctx, req)
@@ -43,14 +63,7 @@ func GrpcUnaryServerInterceptor(logger *Logger) grpc.UnaryServerInterceptor {
 			"method", info.FullMethod,
 			"grpc_code", grpcStatus.String(),
 			"latency_ms", latency.Milliseconds(),
-		}
-
-		// Add trace context if present
-		if traceID != "" && traceID != "00000000000000000000000000000000" {
-			fields = append(fields, "trace_id", traceID)
-		}
-		if spanID != "" && spanID != "0000000000000000" {
-			fields = append(fields, "span_id", spanID)
+			"request_id", requestID,
 		}
 
 		// Add error if present
@@ -58,16 +71,16 @@ func GrpcUnaryServerInterceptor(logger *Logger) grpc.UnaryServerInterceptor {
 			fields = append(fields, "error", err.Error())
 		}
 
-		// Log based on gRPC status code
+		// Log based on gRPC status code (trace context is attached from ctx)
 		switch grpcStatus {
 		case codes.OK:
-			logger.Info("gRPC Request", fields...)
+			logger.InfoCtx(ctx, "gRPC Request", fields...)
 		case codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists,
 			codes.PermissionDenied, codes.Unauthenticated, codes.FailedPrecondition,
 			codes.OutOfRange:
-			logger.Warn("gRPC Client Error", fields...)
+			logger.WarnCtx(ctx, "gRPC Client Error", fields...)
 		default:
-			logger.Error("gRPC Server Error", fields...)
+			logger.ErrorCtx(ctx, "gRPC Server Error", fields...)
 		}
 
 		return resp, err
@@ -84,15 +97,13 @@ func GrpcStreamServerInterceptor(logger *Logger) grpc.StreamServerInterceptor {
 	) error {
 		start := time.Now()
 		ctx := stream.Context()
+		requestID := grpcRequestID(ctx)
 
-		// Extract trace context if available
-		span := trace.SpanFromContext(ctx)
-		spanContext := span.SpanContext()
-		traceID := spanContext.TraceID().String()
-		spanID := spanContext.SpanID().String()
+		// Expose a request-scoped logger to the handler (see LoggerFromContext)
+		ctx = ContextWithLogger(ctx, grpcRequestLogger(ctx, logger, info.FullMethod, requestID))
 
 		// Call the handler
-		err := handler(srv, stream)
+		err := handler(srv, &contextServerStream{ServerStream: stream, ctx: ctx})
 
 		// Calculate latency
 		latency := time.Since(start)
@@ -107,14 +118,7 @@ func GrpcStreamServerInterceptor(logger *Logger) grpc.StreamServerInterceptor {
 			"latency_ms", latency.Milliseconds(),
 			"is_client_stream", info.IsClientStream,
 			"is_server_stream", info.IsServerStream,
-		}
-
-		// Add trace context if present
-		if traceID != "" && traceID != "00000000000000000000000000000000" {
-			fields = append(fields, "trace_id", traceID)
-		}
-		if spanID != "" && spanID != "0000000000000000" {
-			fields = append(fields, "span_id", spanID)
+			"request_id", requestID,
 		}
 
 		// Add error if present
@@ -122,16 +126,16 @@ func GrpcStreamServerInterceptor(logger *Logger) grpc.StreamServerInterceptor {
 			fields = append(fields, "error", err.Error())
 		}
 
-		// Log based on gRPC status code
+		// Log based on gRPC status code (trace context is attached from ctx)
 		switch grpcStatus {
 		case codes.OK:
-			logger.Info("gRPC Stream Request", fields...)
+			logger.InfoCtx(ctx, "gRPC Stream Request", fields...)
 		case codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists,
 			codes.PermissionDenied, codes.Unauthenticated, codes.FailedPrecondition,
 			codes.OutOfRange:
-			logger.Warn("gRPC Stream Client Error", fields...)
+			logger.WarnCtx(ctx, "gRPC Stream Client Error", fields...)
 		default:
-			logger.Error("gRPC Stream Server Error", fields...)
+			logger.ErrorCtx(ctx, "gRPC Stream Server Error", fields...)
 		}
 
 		return err
@@ -149,24 +153,21 @@ func GrpcUnaryRecoveryInterceptor(logger *Logger) grpc.UnaryServerInterceptor {
 		defer func() {
 			if r := recover(); r != nil {
 				// Extract trace context
-				span := trace.SpanFromContext(ctx)
-				spanContext := span.SpanContext()
-				traceID := spanContext.TraceID().String()
+				spanContext := trace.SpanContextFromContext(ctx)
 
 				// Get stack trace
 				stack := string(debug.Stack())
 
 				// Log the panic with full context
-				logger.Error("Panic recovered in gRPC handler",
+				logger.ErrorCtx(ctx, "Panic recovered in gRPC handler",
 					"error", fmt.Sprintf("%v", r),
-					"trace_id", traceID,
 					"method", info.FullMethod,
 					"stack", stack,
 				)
 
 				// Inject trace_id into response metadata if available
-				if traceID != "" && traceID != "00000000000000000000000000000000" {
-					md := metadata.Pairs("trace_id", traceID)
+				if spanContext.HasTraceID() {
+					md := metadata.Pairs("trace_id", spanContext.TraceID().String())
 					if err := setTrailer(ctx, md); err != nil {
 						logger.Warn("failed to set trailer", "error", err)
 					}
@@ -202,17 +203,14 @@ func GrpcStreamRecoveryInterceptor(logger *Logger) grpc.StreamServerInterceptor
 				ctx := stream.Context()
 
 				// Extract trace context
-				span := trace.SpanFromContext(ctx)
-				spanContext := span.SpanContext()
-				traceID := spanContext.TraceID().String()
+				spanContext := trace.SpanContextFromContext(ctx)
 
 				// Get stack trace
 				stack := string(debug.Stack())
 
 				// Log the panic with full context
-				logger.Error("Panic recovered in gRPC stream handler",
+				logger.ErrorCtx(ctx, "Panic recovered in gRPC stream handler",
 					"error", fmt.Sprintf("%v", r),
-					"trace_id", traceID,
 					"method", info.FullMethod,
 					"is_client_stream", info.IsClientStream,
 					"is_server_stream", info.IsServerStream,
@@ -220,8 +218,8 @@ func GrpcStreamRecoveryInterceptor(logger *Logger) grpc.StreamServerInterceptor
 				)
 
 				// Inject trace_id into response metadata if available
-				if traceID != "" && traceID != "00000000000000000000000000000000" {
-					md := metadata.Pairs("trace_id", traceID)
+				if spanContext.HasTraceID() {
+					md := metadata.Pairs("trace_id", spanContext.TraceID().String())
 					stream.SetTrailer(md)
 				}
 
@@ -234,19 +232,23 @@ func GrpcStreamRecoveryInterceptor(logger *Logger) grpc.StreamServerInterceptor
 	}
 }
 
-// GrpcUnaryInterceptors returns a chain of unary interceptors (recovery + logging)
+// GrpcUnaryInterceptors returns a chain of unary interceptors (tracing + metrics + recovery + logging)
 // Usage: grpc.NewServer(grpc.ChainUnaryInterceptor(observability.GrpcUnaryInterceptors(logger)...))
 func GrpcUnaryInterceptors(logger *Logger) []grpc.UnaryServerInterceptor {
 	return []grpc.UnaryServerInterceptor{
+		GrpcUnaryTracingInterceptor(),
+		GrpcUnaryMetricsInterceptor(),
 		GrpcUnaryRecoveryInterceptor(logger),
 		GrpcUnaryServerInterceptor(logger),
 	}
 }
 
-// GrpcStreamInterceptors returns a chain of stream interceptors (recovery + logging)
+// GrpcStreamInterceptors returns a chain of stream interceptors (tracing + metrics + recovery + logging)
 // Usage: grpc.NewServer(grpc.ChainStreamInterceptor(observability.GrpcStreamInterceptors(logger)...))
 func GrpcStreamInterceptors(logger *Logger) []grpc.StreamServerInterceptor {
 	return []grpc.StreamServerInterceptor{
+		GrpcStreamTracingInterceptor(),
+		GrpcStreamMetricsInterceptor(),
 		GrpcStreamRecoveryInterceptor(logger),
 		GrpcStreamServerInterceptor(logger),
 	}
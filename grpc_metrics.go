@@ -0,0 +1,134 @@
+package observability
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// grpcCodeKey tags the client and server RPC metrics with the status code name (e.g. NotFound)
+const grpcCodeKey = attribute.Key("grpc_code")
+
+// grpcServerInstruments holds the RPC server instruments recorded by the metrics interceptors
+type grpcServerInstruments struct {
+	duration        metric.Float64Histogram
+	requestsPerRPC  metric.Int64Histogram
+	responsesPerRPC metric.Int64Histogram
+}
+
+func newGrpcServerInstruments() *grpcServerInstruments {
+	meter := GetMeter("grpc-server")
+	inst := &grpcServerInstruments{}
+
+	var err error
+	if inst.duration, err = meter.Float64Histogram(
+		"rpc.server.duration",
+		metric.WithDescription("Measures the duration of inbound gRPC calls"),
+		metric.WithUnit("ms"),
+	); err != nil {
+		otel.Handle(err)
+	}
+	if inst.requestsPerRPC, err = meter.Int64Histogram(
+		"rpc.server.requests_per_rpc",
+		metric.WithDescription("Measures the number of messages received per RPC"),
+		metric.WithUnit("{count}"),
+	); err != nil {
+		otel.Handle(err)
+	}
+	if inst.responsesPerRPC, err = meter.Int64Histogram(
+		"rpc.server.responses_per_rpc",
+		metric.WithDescription("Measures the number of messages sent per RPC"),
+		metric.WithUnit("{count}"),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	return inst
+}
+
+// record records duration and message counts for a finished RPC
+func (inst *grpcServerInstruments) record(ctx context.Context, fullMethod string, start time.Time, err error, received, sent int64) {
+	attrs := append(grpcSpanAttributes(fullMethod), grpcCodeKey.String(status.Code(err).String()))
+	recordAttrs := metric.WithAttributeSet(attribute.NewSet(attrs...))
+
+	inst.duration.Record(ctx, float64(time.Since(start))/float64(time.Millisecond), recordAttrs)
+	inst.requestsPerRPC.Record(ctx, received, recordAttrs)
+	inst.responsesPerRPC.Record(ctx, sent, recordAttrs)
+}
+
+// countingServerStream wraps grpc.ServerStream to count messages sent and received
+type countingServerStream struct {
+	grpc.ServerStream
+	received atomic.Int64
+	sent     atomic.Int64
+}
+
+// RecvMsg counts successfully received messages
+func (s *countingServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.received.Add(1)
+	}
+	return err
+}
+
+// SendMsg counts successfully sent messages
+func (s *countingServerStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.sent.Add(1)
+	}
+	return err
+}
+
+// GrpcUnaryMetricsInterceptor records OpenTelemetry RPC server metrics for gRPC unary requests
+func GrpcUnaryMetricsInterceptor() grpc.UnaryServerInterceptor {
+	inst := newGrpcServerInstruments()
+
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		// A unary call always receives one message and sends one only on success
+		var sent int64
+		if err == nil {
+			sent = 1
+		}
+		inst.record(ctx, info.FullMethod, start, err, 1, sent)
+
+		return resp, err
+	}
+}
+
+// GrpcStreamMetricsInterceptor records OpenTelemetry RPC server metrics for gRPC streaming requests
+func GrpcStreamMetricsInterceptor() grpc.StreamServerInterceptor {
+	inst := newGrpcServerInstruments()
+
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		start := time.Now()
+		counting := &countingServerStream{ServerStream: stream}
+
+		err := handler(srv, counting)
+
+		inst.record(stream.Context(), info.FullMethod, start, err, counting.received.Load(), counting.sent.Load())
+
+		return err
+	}
+}
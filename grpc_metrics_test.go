@@ -0,0 +1,121 @@
+package observability
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// countingMockServerStream is a mock ServerStream that yields a fixed number of inbound messages
+type countingMockServerStream struct {
+	mockServerStream
+	pending int
+}
+
+func (m *countingMockServerStream) RecvMsg(msg interface{}) error {
+	if m.pending == 0 {
+		return io.EOF
+	}
+	m.pending--
+	return nil
+}
+
+func (m *countingMockServerStream) SendMsg(msg interface{}) error { return nil }
+
+func TestGrpcUnaryMetricsInterceptor(t *testing.T) {
+	reader := setupTestMetrics(t)
+
+	interceptor := GrpcUnaryMetricsInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/TestMethod"}
+
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &mockResponse{}, nil
+	}
+	notFound := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "missing")
+	}
+
+	_, _ = interceptor(context.Background(), &mockRequest{}, info, ok)
+	_, _ = interceptor(context.Background(), &mockRequest{}, info, ok)
+	_, _ = interceptor(context.Background(), &mockRequest{}, info, notFound)
+
+	m, found := findMetric(t, reader, "rpc.server.duration")
+	if !found {
+		t.Fatal("expected rpc.server.duration to be recorded")
+	}
+	hist := m.Data.(metricdata.Histogram[float64])
+	if len(hist.DataPoints) != 2 {
+		t.Fatalf("expected 2 series (OK and NotFound), got %d", len(hist.DataPoints))
+	}
+
+	counts := map[string]uint64{}
+	for _, dp := range hist.DataPoints {
+		code, _ := dp.Attributes.Value("grpc_code")
+		counts[code.AsString()] = dp.Count
+
+		if v, _ := dp.Attributes.Value("rpc.service"); v.AsString() != "test.Service" {
+			t.Errorf("expected rpc.service=test.Service, got %v", v)
+		}
+		if v, _ := dp.Attributes.Value("rpc.method"); v.AsString() != "TestMethod" {
+			t.Errorf("expected rpc.method=TestMethod, got %v", v)
+		}
+	}
+	if counts["OK"] != 2 || counts["NotFound"] != 1 {
+		t.Errorf("unexpected per-code counts: %v", counts)
+	}
+
+	m, found = findMetric(t, reader, "rpc.server.responses_per_rpc")
+	if !found {
+		t.Fatal("expected rpc.server.responses_per_rpc to be recorded")
+	}
+	for _, dp := range m.Data.(metricdata.Histogram[int64]).DataPoints {
+		code, _ := dp.Attributes.Value("grpc_code")
+		if code.AsString() == "NotFound" && dp.Sum != 0 {
+			t.Errorf("expected no responses for failed call, got %d", dp.Sum)
+		}
+	}
+}
+
+func TestGrpcStreamMetricsInterceptor(t *testing.T) {
+	reader := setupTestMetrics(t)
+
+	interceptor := GrpcStreamMetricsInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/Chat", IsClientStream: true, IsServerStream: true}
+	stream := &countingMockServerStream{mockServerStream: mockServerStream{ctx: context.Background()}, pending: 3}
+
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		for {
+			if err := stream.RecvMsg(&mockRequest{}); err == io.EOF {
+				return nil
+			}
+			if err := stream.SendMsg(&mockResponse{}); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := interceptor(nil, stream, info, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	m, found := findMetric(t, reader, "rpc.server.requests_per_rpc")
+	if !found {
+		t.Fatal("expected rpc.server.requests_per_rpc to be recorded")
+	}
+	if dp := m.Data.(metricdata.Histogram[int64]).DataPoints[0]; dp.Sum != 3 {
+		t.Errorf("expected 3 received messages, got %d", dp.Sum)
+	}
+
+	m, found = findMetric(t, reader, "rpc.server.responses_per_rpc")
+	if !found {
+		t.Fatal("expected rpc.server.responses_per_rpc to be recorded")
+	}
+	if dp := m.Data.(metricdata.Histogram[int64]).DataPoints[0]; dp.Sum != 3 {
+		t.Errorf("expected 3 sent messages, got %d", dp.Sum)
+	}
+}
@@ -0,0 +1,160 @@
+package observability
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// metadataCarrier adapts gRPC metadata to the OpenTelemetry TextMapCarrier interface
+type metadataCarrier metadata.MD
+
+// Get returns the first value associated with the key
+func (mc metadataCarrier) Get(key string) string {
+	values := metadata.MD(mc).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// Set stores the key-value pair, replacing any existing values
+func (mc metadataCarrier) Set(key, value string) {
+	metadata.MD(mc).Set(key, value)
+}
+
+// Keys lists the keys stored in the carrier
+func (mc metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(mc))
+	for k := range mc {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// splitGrpcMethod splits a full gRPC method name ("/pkg.Service/Method")
+// into its service and method parts
+func splitGrpcMethod(fullMethod string) (string, string) {
+	name := strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return "", name
+}
+
+// grpcSpanName returns the span name for a full gRPC method name
+func grpcSpanName(fullMethod string) string {
+	return strings.TrimPrefix(fullMethod, "/")
+}
+
+// grpcSpanAttributes returns the RPC semantic convention attributes for a method
+func grpcSpanAttributes(fullMethod string) []attribute.KeyValue {
+	service, method := splitGrpcMethod(fullMethod)
+	attrs := []attribute.KeyValue{semconv.RPCSystemGRPC}
+	if service != "" {
+		attrs = append(attrs, semconv.RPCService(service))
+	}
+	if method != "" {
+		attrs = append(attrs, semconv.RPCMethod(method))
+	}
+	return attrs
+}
+
+// isGrpcServerError reports whether a gRPC code indicates a server-side failure.
+// Client errors (e.g. NotFound, InvalidArgument) are not treated as span errors on the server.
+func isGrpcServerError(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented, codes.Internal,
+		codes.Unavailable, codes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
+
+// finishGrpcServerSpan records the gRPC status code on the span and sets its status
+func finishGrpcServerSpan(span trace.Span, err error) {
+	st, _ := status.FromError(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(st.Code())))
+
+	if err != nil {
+		span.RecordError(err)
+	}
+	if isGrpcServerError(st.Code()) {
+		span.SetStatus(otelcodes.Error, st.Message())
+	}
+}
+
+// startGrpcServerSpan extracts W3C trace context from incoming metadata and starts a server span
+func startGrpcServerSpan(ctx context.Context, tracer trace.Tracer, fullMethod string) (context.Context, trace.Span) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		md = metadata.MD{}
+	}
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+
+	return tracer.Start(ctx, grpcSpanName(fullMethod),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(grpcSpanAttributes(fullMethod)...),
+	)
+}
+
+// contextServerStream wraps grpc.ServerStream to hand an enriched context to the handler
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the enriched context
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// GrpcUnaryTracingInterceptor creates OpenTelemetry server spans for gRPC unary requests
+func GrpcUnaryTracingInterceptor() grpc.UnaryServerInterceptor {
+	tracer := otel.Tracer("grpc-server")
+
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, span := startGrpcServerSpan(ctx, tracer, info.FullMethod)
+		defer span.End()
+
+		resp, err := handler(ctx, req)
+		finishGrpcServerSpan(span, err)
+
+		return resp, err
+	}
+}
+
+// GrpcStreamTracingInterceptor creates OpenTelemetry server spans for gRPC streaming requests
+func GrpcStreamTracingInterceptor() grpc.StreamServerInterceptor {
+	tracer := otel.Tracer("grpc-server")
+
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, span := startGrpcServerSpan(stream.Context(), tracer, info.FullMethod)
+		defer span.End()
+
+		err := handler(srv, &contextServerStream{ServerStream: stream, ctx: ctx})
+		finishGrpcServerSpan(span, err)
+
+		return err
+	}
+}
@@ -0,0 +1,201 @@
+package observability
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// setupTestTracing installs an in-memory tracer provider and W3C propagator as globals
+func setupTestTracing(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	return recorder
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestSplitGrpcMethod(t *testing.T) {
+	tests := []struct {
+		fullMethod string
+		service    string
+		method     string
+	}{
+		{"/hello.HelloService/SayHello", "hello.HelloService", "SayHello"},
+		{"hello.HelloService/SayHello", "hello.HelloService", "SayHello"},
+		{"/SayHello", "", "SayHello"},
+	}
+
+	for _, tt := range tests {
+		service, method := splitGrpcMethod(tt.fullMethod)
+		if service != tt.service || method != tt.method {
+			t.Errorf("splitGrpcMethod(%q) = (%q, %q), want (%q, %q)",
+				tt.fullMethod, service, method, tt.service, tt.method)
+		}
+	}
+}
+
+func TestGrpcUnaryTracingInterceptor(t *testing.T) {
+	recorder := setupTestTracing(t)
+
+	// Simulate an upstream caller sending a traceparent header
+	parentTraceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	parentSpanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	md := metadata.Pairs("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	var handlerSpan trace.SpanContext
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handlerSpan = trace.SpanFromContext(ctx).SpanContext()
+		return &mockResponse{Message: "ok"}, nil
+	}
+
+	interceptor := GrpcUnaryTracingInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/TestMethod"}
+	if _, err := interceptor(ctx, &mockRequest{}, info, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+
+	if span.Name() != "test.Service/TestMethod" {
+		t.Errorf("expected span name 'test.Service/TestMethod', got %q", span.Name())
+	}
+	if span.SpanKind() != trace.SpanKindServer {
+		t.Errorf("expected server span kind, got %v", span.SpanKind())
+	}
+	if span.SpanContext().TraceID() != parentTraceID {
+		t.Errorf("expected trace id %s, got %s", parentTraceID, span.SpanContext().TraceID())
+	}
+	if span.Parent().SpanID() != parentSpanID {
+		t.Errorf("expected parent span id %s, got %s", parentSpanID, span.Parent().SpanID())
+	}
+	if handlerSpan.SpanID() != span.SpanContext().SpanID() {
+		t.Error("expected handler context to carry the server span")
+	}
+
+	if v, ok := spanAttribute(span, "rpc.system"); !ok || v.AsString() != "grpc" {
+		t.Errorf("expected rpc.system=grpc, got %v", v)
+	}
+	if v, ok := spanAttribute(span, "rpc.service"); !ok || v.AsString() != "test.Service" {
+		t.Errorf("expected rpc.service=test.Service, got %v", v)
+	}
+	if v, ok := spanAttribute(span, "rpc.method"); !ok || v.AsString() != "TestMethod" {
+		t.Errorf("expected rpc.method=TestMethod, got %v", v)
+	}
+	if v, ok := spanAttribute(span, "rpc.grpc.status_code"); !ok || v.AsInt64() != int64(codes.OK) {
+		t.Errorf("expected rpc.grpc.status_code=0, got %v", v)
+	}
+	if span.Status().Code == otelcodes.Error {
+		t.Error("expected non-error span status for OK response")
+	}
+}
+
+func TestGrpcUnaryTracingInterceptor_Status(t *testing.T) {
+	recorder := setupTestTracing(t)
+
+	tests := []struct {
+		name        string
+		code        codes.Code
+		expectError bool
+	}{
+		{name: "NotFound_Is_Not_Server_Error", code: codes.NotFound, expectError: false},
+		{name: "Internal_Is_Server_Error", code: codes.Internal, expectError: true},
+		{name: "Unavailable_Is_Server_Error", code: codes.Unavailable, expectError: true},
+	}
+
+	interceptor := GrpcUnaryTracingInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/TestMethod"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder.Reset()
+
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, status.Error(tt.code, "failure")
+			}
+			_, _ = interceptor(context.Background(), &mockRequest{}, info, handler)
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("expected 1 span, got %d", len(spans))
+			}
+			isError := spans[0].Status().Code == otelcodes.Error
+			if isError != tt.expectError {
+				t.Errorf("expected error status %v, got %v", tt.expectError, isError)
+			}
+			if v, _ := spanAttribute(spans[0], "rpc.grpc.status_code"); v.AsInt64() != int64(tt.code) {
+				t.Errorf("expected rpc.grpc.status_code=%d, got %v", tt.code, v)
+			}
+		})
+	}
+}
+
+func TestGrpcStreamTracingInterceptor(t *testing.T) {
+	recorder := setupTestTracing(t)
+
+	md := metadata.Pairs("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	stream := &mockServerStream{ctx: metadata.NewIncomingContext(context.Background(), md)}
+
+	var handlerSpan trace.SpanContext
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		handlerSpan = trace.SpanFromContext(stream.Context()).SpanContext()
+		return status.Error(codes.Internal, "boom")
+	}
+
+	interceptor := GrpcStreamTracingInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/StreamMethod", IsServerStream: true}
+	if err := interceptor(nil, stream, info, handler); err == nil {
+		t.Fatal("expected error from handler")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+
+	if span.Name() != "test.Service/StreamMethod" {
+		t.Errorf("expected span name 'test.Service/StreamMethod', got %q", span.Name())
+	}
+	if span.SpanContext().TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected propagated trace id, got %s", span.SpanContext().TraceID())
+	}
+	if handlerSpan.SpanID() != span.SpanContext().SpanID() {
+		t.Error("expected stream context to carry the server span")
+	}
+	if span.Status().Code != otelcodes.Error {
+		t.Errorf("expected error span status, got %v", span.Status().Code)
+	}
+}
@@ -0,0 +1,377 @@
+package observability
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// LivenessPath and ReadinessPath are the health endpoints served next to the metrics endpoint
+const (
+	LivenessPath  = "/livez"
+	ReadinessPath = "/readyz"
+)
+
+// Health statuses reported for checks and for a whole report
+const (
+	// HealthStatusPass means every check passed
+	HealthStatusPass = "pass"
+	// HealthStatusWarn means only non-critical checks failed
+	HealthStatusWarn = "warn"
+	// HealthStatusFail means a critical check failed
+	HealthStatusFail = "fail"
+)
+
+// defaultHealthCheckTimeout bounds checks registered without WithCheckTimeout
+const defaultHealthCheckTimeout = 5 * time.Second
+
+// defaultHealthRefreshInterval is the default interval between two background readiness runs
+// that keep the health.check.status gauge current when nothing probes /readyz
+const defaultHealthRefreshInterval = 15 * time.Second
+
+// HealthCheck reports a component's health; a non-nil error marks it unhealthy.
+// It should honor ctx, which is cancelled once the check's timeout expires.
+type HealthCheck func(ctx context.Context) error
+
+// HealthCheckOption customizes a registered check
+type HealthCheckOption func(*healthCheck)
+
+// WithCheckTimeout bounds the check's duration (default 5s); a check still running afterwards fails
+func WithCheckTimeout(timeout time.Duration) HealthCheckOption {
+	return func(c *healthCheck) {
+		if timeout > 0 {
+			c.timeout = timeout
+		}
+	}
+}
+
+// NonCritical reports the check's failures without failing readiness (the report becomes "warn")
+func NonCritical() HealthCheckOption {
+	return func(c *healthCheck) {
+		c.critical = false
+	}
+}
+
+// Liveness also runs the check on /livez. Only use it for failures a restart fixes, such as a
+// deadlocked worker; dependencies like databases belong to readiness only.
+func Liveness() HealthCheckOption {
+	return func(c *healthCheck) {
+		c.liveness = true
+	}
+}
+
+// healthCheck is a registered check and its settings
+type healthCheck struct {
+	name     string
+	check    HealthCheck
+	timeout  time.Duration
+	critical bool
+	liveness bool
+}
+
+// HealthCheckResult is the outcome of one check
+type HealthCheckResult struct {
+	Name     string  `json:"name"`
+	Status   string  `json:"status"`
+	Critical bool    `json:"critical"`
+	Duration float64 `json:"duration_ms"`
+	Error    string  `json:"error,omitempty"`
+}
+
+// HealthReport is the outcome of a liveness or readiness probe
+type HealthReport struct {
+	Status string              `json:"status"`
+	Checks []HealthCheckResult `json:"checks"`
+}
+
+// HealthRegistry holds the named checks behind /livez, /readyz, the health_check_status gauge
+// and the gRPC health service. It is safe for concurrent use.
+type HealthRegistry struct {
+	mu     sync.RWMutex
+	checks []*healthCheck
+	// latest holds the last result of every registered check, observed by the gauge
+	latest map[string]HealthCheckResult
+	// refreshInterval is the interval between two background readiness runs
+	refreshInterval time.Duration
+}
+
+// HealthRegistryOption customizes a HealthRegistry
+type HealthRegistryOption func(*HealthRegistry)
+
+// WithHealthRefreshInterval sets how often InitOtel runs the readiness checks in the background to
+// refresh the health.check.status gauge (default 15s)
+func WithHealthRefreshInterval(interval time.Duration) HealthRegistryOption {
+	return func(r *HealthRegistry) {
+		if interval > 0 {
+			r.refreshInterval = interval
+		}
+	}
+}
+
+// NewHealthRegistry creates an empty registry; with no checks every probe passes
+func NewHealthRegistry(opts ...HealthRegistryOption) *HealthRegistry {
+	r := &HealthRegistry{refreshInterval: defaultHealthRefreshInterval}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+	return r
+}
+
+// Register adds a critical readiness check. Names must be unique; they also serve as gRPC
+// health service names.
+func (r *HealthRegistry) Register(name string, check HealthCheck, opts ...HealthCheckOption) error {
+	if name == "" {
+		return fmt.Errorf("health check name is required")
+	}
+	if check == nil {
+		return fmt.Errorf("health check %q: check function is nil", name)
+	}
+
+	c := &healthCheck{name: name, check: check, timeout: defaultHealthCheckTimeout, critical: true}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.checks {
+		if existing.name == name {
+			return fmt.Errorf("health check %q is already registered", name)
+		}
+	}
+	r.checks = append(r.checks, c)
+	return nil
+}
+
+// Unregister removes the named check, if present
+func (r *HealthRegistry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, c := range r.checks {
+		if c.name == name {
+			r.checks = append(r.checks[:i], r.checks[i+1:]...)
+			delete(r.latest, name)
+			return
+		}
+	}
+}
+
+// Liveness runs the checks registered with Liveness
+func (r *HealthRegistry) Liveness(ctx context.Context) HealthReport {
+	return r.run(ctx, func(c *healthCheck) bool { return c.liveness })
+}
+
+// Readiness runs every registered check
+func (r *HealthRegistry) Readiness(ctx context.Context) HealthReport {
+	return r.run(ctx, func(*healthCheck) bool { return true })
+}
+
+// CheckNamed runs a single check; ok is false when no check has that name
+func (r *HealthRegistry) CheckNamed(ctx context.Context, name string) (result HealthCheckResult, ok bool) {
+	report := r.run(ctx, func(c *healthCheck) bool { return c.name == name })
+	if len(report.Checks) == 0 {
+		return HealthCheckResult{}, false
+	}
+	return report.Checks[0], true
+}
+
+// Names returns the registered check names in registration order
+func (r *HealthRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.checks))
+	for _, c := range r.checks {
+		names = append(names, c.name)
+	}
+	return names
+}
+
+// run executes the selected checks concurrently and aggregates their results
+func (r *HealthRegistry) run(ctx context.Context, selected func(*healthCheck) bool) HealthReport {
+	r.mu.RLock()
+	var checks []*healthCheck
+	for _, c := range r.checks {
+		if selected(c) {
+			checks = append(checks, c)
+		}
+	}
+	r.mu.RUnlock()
+
+	report := HealthReport{Status: HealthStatusPass, Checks: make([]HealthCheckResult, len(checks))}
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = c.run(ctx)
+		}()
+	}
+	wg.Wait()
+	r.remember(checks, report.Checks)
+
+	for _, result := range report.Checks {
+		if result.Status != HealthStatusFail {
+			continue
+		}
+		if result.Critical {
+			report.Status = HealthStatusFail
+		} else if report.Status == HealthStatusPass {
+			report.Status = HealthStatusWarn
+		}
+	}
+	return report
+}
+
+// remember caches the results of checks that are still registered, so a check unregistered
+// while it ran does not reappear in the gauge
+func (r *HealthRegistry) remember(checks []*healthCheck, results []HealthCheckResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.latest == nil {
+		r.latest = make(map[string]HealthCheckResult, len(results))
+	}
+	for i, c := range checks {
+		if slices.Contains(r.checks, c) {
+			r.latest[c.name] = results[i]
+		}
+	}
+}
+
+// run executes the check within its timeout, turning panics into failures
+func (c *healthCheck) run(ctx context.Context) HealthCheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if rec := recover(); rec != nil {
+				done <- fmt.Errorf("panic: %v", rec)
+			}
+		}()
+		done <- c.check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		// The check ignored its context; report it without waiting any longer
+		err = fmt.Errorf("timed out after %s", c.timeout)
+	}
+
+	result := HealthCheckResult{
+		Name:     c.name,
+		Status:   HealthStatusPass,
+		Critical: c.critical,
+		Duration: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = HealthStatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// LivenessHandler serves the liveness report as JSON, with status 503 when a check fails
+func (r *HealthRegistry) LivenessHandler() http.Handler {
+	return healthHandler(r.Liveness)
+}
+
+// ReadinessHandler serves the readiness report as JSON, with status 503 when a critical check fails
+func (r *HealthRegistry) ReadinessHandler() http.Handler {
+	return healthHandler(r.Readiness)
+}
+
+// healthHandler writes the report produced by probe
+func healthHandler(probe func(context.Context) HealthReport) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		report := probe(req.Context())
+
+		status := http.StatusOK
+		if report.Status == HealthStatusFail {
+			status = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
+
+// useMeterProvider exports the latest result of every check as the health.check.status gauge
+// (health_check_status in Prometheus): 1 when the check passes, 0 when it fails. Collections
+// never run checks; results come from probes and from the background refresh.
+func (r *HealthRegistry) useMeterProvider(mp metric.MeterProvider) {
+	_, err := mp.Meter("health").Int64ObservableGauge(
+		"health.check.status",
+		metric.WithDescription("Health check status: 1 when the check passes, 0 when it fails"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			for _, c := range r.checks {
+				result, ok := r.latest[c.name]
+				if !ok {
+					continue
+				}
+				var value int64
+				if result.Status == HealthStatusPass {
+					value = 1
+				}
+				o.Observe(value, metric.WithAttributes(
+					attribute.String("check", result.Name),
+					attribute.Bool("critical", result.Critical),
+				))
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+}
+
+// refresh runs the readiness checks every refreshInterval until the returned function is
+// called, so the gauge does not go stale without /readyz traffic
+func (r *HealthRegistry) refresh() func(context.Context) error {
+	stop, done := make(chan struct{}), make(chan struct{})
+	interval := r.refreshInterval
+	if interval <= 0 {
+		interval = defaultHealthRefreshInterval
+	}
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				r.Readiness(context.Background())
+			}
+		}
+	}()
+	return func(ctx context.Context) error {
+		close(stop)
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
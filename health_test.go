@@ -0,0 +1,280 @@
+package observability
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func healthyCheck(context.Context) error { return nil }
+
+func failingCheck(context.Context) error { return errors.New("connection refused") }
+
+func TestHealthRegistry_Register(t *testing.T) {
+	r := NewHealthRegistry()
+
+	if err := r.Register("db", healthyCheck); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if err := r.Register("db", healthyCheck); err == nil {
+		t.Error("expected an error for a duplicate name")
+	}
+	if err := r.Register("", healthyCheck); err == nil {
+		t.Error("expected an error for an empty name")
+	}
+	if err := r.Register("cache", nil); err == nil {
+		t.Error("expected an error for a nil check")
+	}
+	if err := r.Register("cache", healthyCheck, NonCritical()); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if got := r.Names(); !slices.Equal(got, []string{"db", "cache"}) {
+		t.Errorf("expected [db cache], got %v", got)
+	}
+
+	r.Unregister("db")
+	if got := r.Names(); !slices.Equal(got, []string{"cache"}) {
+		t.Errorf("expected [cache] after Unregister, got %v", got)
+	}
+}
+
+func TestHealthRegistry_Reports(t *testing.T) {
+	tests := []struct {
+		name          string
+		register      func(r *HealthRegistry)
+		wantReadiness string
+		wantLiveness  string
+	}{
+		{
+			name:          "No Checks",
+			register:      func(*HealthRegistry) {},
+			wantReadiness: HealthStatusPass,
+			wantLiveness:  HealthStatusPass,
+		},
+		{
+			name: "Critical Failure",
+			register: func(r *HealthRegistry) {
+				_ = r.Register("db", failingCheck)
+				_ = r.Register("worker", healthyCheck, Liveness())
+			},
+			wantReadiness: HealthStatusFail,
+			wantLiveness:  HealthStatusPass,
+		},
+		{
+			name: "Non-Critical Failure",
+			register: func(r *HealthRegistry) {
+				_ = r.Register("db", healthyCheck)
+				_ = r.Register("cache", failingCheck, NonCritical())
+			},
+			wantReadiness: HealthStatusWarn,
+			wantLiveness:  HealthStatusPass,
+		},
+		{
+			name: "Liveness Failure",
+			register: func(r *HealthRegistry) {
+				_ = r.Register("worker", failingCheck, Liveness())
+			},
+			wantReadiness: HealthStatusFail,
+			wantLiveness:  HealthStatusFail,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewHealthRegistry()
+			tt.register(r)
+
+			if got := r.Readiness(context.Background()).Status; got != tt.wantReadiness {
+				t.Errorf("expected readiness %q, got %q", tt.wantReadiness, got)
+			}
+			if got := r.Liveness(context.Background()).Status; got != tt.wantLiveness {
+				t.Errorf("expected liveness %q, got %q", tt.wantLiveness, got)
+			}
+		})
+	}
+}
+
+func TestHealthRegistry_TimeoutAndPanic(t *testing.T) {
+	r := NewHealthRegistry()
+	_ = r.Register("stuck", func(context.Context) error {
+		// Ignores its context on purpose
+		time.Sleep(time.Second)
+		return nil
+	}, WithCheckTimeout(20*time.Millisecond))
+	_ = r.Register("panics", func(context.Context) error { panic("boom") })
+
+	start := time.Now()
+	report := r.Readiness(context.Background())
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected the timeout to bound the probe, took %s", elapsed)
+	}
+	if report.Status != HealthStatusFail {
+		t.Errorf("expected fail, got %q", report.Status)
+	}
+	for _, result := range report.Checks {
+		if result.Status != HealthStatusFail || result.Error == "" {
+			t.Errorf("expected %s to fail with an error, got %+v", result.Name, result)
+		}
+	}
+
+	if _, ok := r.CheckNamed(context.Background(), "missing"); ok {
+		t.Error("expected CheckNamed to report unknown checks")
+	}
+}
+
+func TestHealthHandlers(t *testing.T) {
+	r := NewHealthRegistry()
+	_ = r.Register("db", failingCheck)
+	_ = r.Register("worker", healthyCheck, Liveness())
+
+	tests := []struct {
+		name       string
+		handler    http.Handler
+		wantStatus int
+		wantChecks []string
+	}{
+		{name: "Readiness", handler: r.ReadinessHandler(), wantStatus: http.StatusServiceUnavailable, wantChecks: []string{"db", "worker"}},
+		{name: "Liveness", handler: r.LivenessHandler(), wantStatus: http.StatusOK, wantChecks: []string{"worker"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("expected JSON content type, got %q", ct)
+			}
+			var report HealthReport
+			if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+				t.Fatalf("failed to decode report: %v", err)
+			}
+			var names []string
+			for _, result := range report.Checks {
+				names = append(names, result.Name)
+			}
+			if !slices.Equal(names, tt.wantChecks) {
+				t.Errorf("expected checks %v, got %v", tt.wantChecks, names)
+			}
+		})
+	}
+}
+
+func TestInitOtel_Health(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	registry := NewHealthRegistry()
+	_ = registry.Register("db", healthyCheck)
+
+	cfg := BaseConfig{
+		ServiceName: "health-service",
+		MetricsMode: "pull",
+		MetricsPath: "/metrics",
+	}
+	tel, err := InitOtelWithOptions(cfg,
+		WithoutGlobals(),
+		WithHealthRegistry(registry),
+		WithSpanExporter(tracetest.NewInMemoryExporter()),
+		WithMetricReader(reader),
+	)
+	if err != nil {
+		t.Fatalf("InitOtelWithOptions failed: %v", err)
+	}
+	defer func() { _ = tel.Shutdown(context.Background()) }()
+
+	if tel.Health() != registry {
+		t.Fatal("expected Health to return the registry passed with WithHealthRegistry")
+	}
+	// Checks registered after init are served too
+	_ = tel.Health().Register("cache", failingCheck, NonCritical())
+
+	for path, want := range map[string]int{LivenessPath: http.StatusOK, ReadinessPath: http.StatusOK} {
+		resp, err := http.Get("http://" + tel.MetricsAddr() + path)
+		if err != nil {
+			t.Fatalf("GET %s failed: %v", path, err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("GET %s: expected status %d, got %d", path, want, resp.StatusCode)
+		}
+	}
+
+	m, ok := findMetric(t, reader, "health.check.status")
+	if !ok {
+		t.Fatal("health.check.status not found")
+	}
+	gauge, ok := m.Data.(metricdata.Gauge[int64])
+	if !ok {
+		t.Fatalf("expected an int64 gauge, got %T", m.Data)
+	}
+	values := map[string]int64{}
+	for _, dp := range gauge.DataPoints {
+		check, _ := dp.Attributes.Value(attribute.Key("check"))
+		values[check.AsString()] = dp.Value
+	}
+	if values["db"] != 1 || values["cache"] != 0 || len(values) != 2 {
+		t.Errorf("expected db=1 and cache=0, got %v", values)
+	}
+}
+
+func TestHealthGauge_ObservesCachedResults(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	registry := NewHealthRegistry(WithHealthRefreshInterval(10 * time.Millisecond))
+	var runs atomic.Int32
+	_ = registry.Register("db", func(context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+	registry.useMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	// Nothing probed yet: collecting neither runs the check nor reports it
+	if _, ok := findMetric(t, reader, "health.check.status"); ok {
+		t.Error("expected no health.check.status data before the first probe")
+	}
+	if runs.Load() != 0 {
+		t.Fatalf("expected collection not to run checks, got %d runs", runs.Load())
+	}
+
+	stop := registry.refresh()
+	deadline := time.Now().Add(time.Second)
+	for runs.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if err := stop(context.Background()); err != nil {
+		t.Fatalf("stopping the refresh failed: %v", err)
+	}
+
+	before := runs.Load()
+	if before == 0 {
+		t.Fatal("expected the background refresh to run the check")
+	}
+	m, ok := findMetric(t, reader, "health.check.status")
+	if !ok {
+		t.Fatal("health.check.status not found after the refresh")
+	}
+	if dps := m.Data.(metricdata.Gauge[int64]).DataPoints; len(dps) != 1 || dps[0].Value != 1 {
+		t.Errorf("expected db=1, got %v", dps)
+	}
+	if runs.Load() != before {
+		t.Error("expected collection not to run checks")
+	}
+
+	// Unregistered checks leave the gauge
+	registry.Unregister("db")
+	if m, ok := findMetric(t, reader, "health.check.status"); ok && len(m.Data.(metricdata.Gauge[int64]).DataPoints) != 0 {
+		t.Error("expected the unregistered check to leave the gauge")
+	}
+}
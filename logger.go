@@ -1,8 +1,13 @@
 package observability
 
 import (
+	"context"
 	"os"
+	"slices"
+	"strings"
 
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -11,35 +16,117 @@ import (
 
 type Logger struct {
 	*zap.SugaredLogger
+	// level is shared by the logger and all of its children, so it can be changed at runtime
+	level zap.AtomicLevel
+	// baggageKeys lists the baggage members attached to context-aware log entries
+	baggageKeys []string
 }
 
 func NewLogger(cfg *BaseConfig) *Logger {
-	level := zapcore.InfoLevel
+	level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
 	service := "unknown"
 	version := "unknown"
+	toStdout, toOTLP := true, false
+	var baggageKeys []string
 
 	if cfg != nil {
 		if parsed, err := zapcore.ParseLevel(cfg.LogLevel); err == nil {
-			level = parsed
+			level.SetLevel(parsed)
 		}
 		service = cfg.ServiceName
 		version = cfg.Version
+		toStdout, toOTLP = cfg.IsLogsStdout(), cfg.IsLogsOTLP()
+		for _, key := range cfg.LogBaggageKeys {
+			if key = strings.TrimSpace(key); key != "" {
+				baggageKeys = append(baggageKeys, key)
+			}
+		}
 	}
 
 	encoderCfg := zap.NewProductionEncoderConfig()
 	encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder
 	encoderCfg.TimeKey = "timestamp"
 
-	core := zapcore.NewCore(
-		zapcore.NewJSONEncoder(encoderCfg),
-		zapcore.AddSync(os.Stdout),
-		level,
-	)
+	var cores []zapcore.Core
+	if toStdout {
+		cores = append(cores, zapcore.NewCore(
+			zapcore.NewJSONEncoder(encoderCfg),
+			zapcore.AddSync(os.Stdout),
+			level,
+		))
+	}
+	if toOTLP {
+		// Tee into the OTel LoggerProvider installed by InitOtel
+		cores = append(cores, newOtelCore(level))
+	}
+	core := zapcore.NewTee(cores...)
 
 	l := zap.New(core, zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel))
 	l = l.With(zap.String("service", service), zap.String("version", version))
 
-	return &Logger{SugaredLogger: l.Sugar()}
+	return &Logger{SugaredLogger: l.Sugar(), level: level, baggageKeys: baggageKeys}
+}
+
+// traceFields returns trace_id, span_id and trace_flags for the span in ctx,
+// or nothing when ctx carries no valid span context
+func traceFields(ctx context.Context) []interface{} {
+	spanContext := trace.SpanContextFromContext(ctx)
+
+	var fields []interface{}
+	if spanContext.HasTraceID() {
+		fields = append(fields, "trace_id", spanContext.TraceID().String())
+	}
+	if spanContext.HasSpanID() {
+		fields = append(fields, "span_id", spanContext.SpanID().String())
+	}
+	if spanContext.IsValid() {
+		fields = append(fields, "trace_flags", spanContext.TraceFlags().String())
+	}
+	return fields
+}
+
+// contextField carries ctx to the OTLP core, which emits the record with it so the SDK fills in
+// the trace ID, span ID and trace flags. Console and JSON encoders skip it.
+func contextField(ctx context.Context) zap.Field {
+	return zap.Field{Key: "context", Type: zapcore.SkipType, Interface: ctx}
+}
+
+// contextFields returns the trace correlation fields, selected baggage members and, when ctx
+// holds a span, the context itself for the OTLP core
+func (l *Logger) contextFields(ctx context.Context) []interface{} {
+	if ctx == nil {
+		return nil
+	}
+
+	fields := traceFields(ctx)
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		fields = append(fields, contextField(ctx))
+	}
+
+	if len(l.baggageKeys) > 0 {
+		bag := baggage.FromContext(ctx)
+		for _, key := range l.baggageKeys {
+			if member := bag.Member(key); member.Key() != "" {
+				fields = append(fields, "baggage."+key, member.Value())
+			}
+		}
+	}
+	return fields
+}
+
+// WithContext returns a child logger carrying trace_id, span_id, trace_flags and
+// selected baggage members from ctx
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	fields := l.contextFields(ctx)
+	if len(fields) == 0 {
+		return l
+	}
+	return l.child(fields...)
+}
+
+// child returns a Logger with additional fields that shares the parent's settings
+func (l *Logger) child(args ...any) *Logger {
+	return &Logger{SugaredLogger: l.SugaredLogger.With(args...), level: l.level, baggageKeys: l.baggageKeys}
 }
 
 // Helper methods for logging
@@ -49,3 +136,17 @@ func (l *Logger) Debug(msg string, args ...any) { l.Debugw(msg, args...) }
 func (l *Logger) Warn(msg string, args ...any)  { l.Warnw(msg, args...) }
 func (l *Logger) Fatal(msg string, args ...any) { l.Fatalw(msg, args...) }
 func (l *Logger) Sync()                         { _ = l.SugaredLogger.Sync() }
+
+// Context-aware helper methods attaching trace correlation fields from ctx
+func (l *Logger) InfoCtx(ctx context.Context, msg string, args ...any) {
+	l.Infow(msg, slices.Concat(args, l.contextFields(ctx))...)
+}
+func (l *Logger) ErrorCtx(ctx context.Context, msg string, args ...any) {
+	l.Errorw(msg, slices.Concat(args, l.contextFields(ctx))...)
+}
+func (l *Logger) DebugCtx(ctx context.Context, msg string, args ...any) {
+	l.Debugw(msg, slices.Concat(args, l.contextFields(ctx))...)
+}
+func (l *Logger) WarnCtx(ctx context.Context, msg string, args ...any) {
+	l.Warnw(msg, slices.Concat(args, l.contextFields(ctx))...)
+}
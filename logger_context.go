@@ -0,0 +1,97 @@
+package observability
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader is the HTTP header (and lowercase gRPC metadata key) carrying the request ID
+const RequestIDHeader = "X-Request-ID"
+
+// ginLoggerKey is the gin.Context key holding the request-scoped logger
+const ginLoggerKey = "observability.logger"
+
+// loggerContextKey is the context key holding the request-scoped logger
+type loggerContextKey struct{}
+
+// defaultLogger is returned by the accessors when no request-scoped logger is present
+var defaultLogger atomic.Pointer[Logger]
+
+// fallbackLogger is the stdout logger DefaultLogger returns until SetDefaultLogger is called
+var fallbackLogger = sync.OnceValue(func() *Logger { return NewLogger(nil) })
+
+// SetDefaultLogger sets the logger returned by LoggerFromContext and LoggerFromGin
+// when no request-scoped logger is available
+func SetDefaultLogger(l *Logger) {
+	defaultLogger.Store(l)
+}
+
+// DefaultLogger returns the package default logger: the one set with SetDefaultLogger, else a
+// stdout logger built once from a nil config
+func DefaultLogger() *Logger {
+	if l := defaultLogger.Load(); l != nil {
+		return l
+	}
+	return fallbackLogger()
+}
+
+// ContextWithLogger returns a copy of ctx carrying the logger
+func ContextWithLogger(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, l)
+}
+
+// LoggerFromContext returns the request-scoped logger stored in ctx, or the package default
+func LoggerFromContext(ctx context.Context) *Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(loggerContextKey{}).(*Logger); ok && l != nil {
+			return l
+		}
+	}
+	return DefaultLogger()
+}
+
+// LoggerFromGin returns the request-scoped logger stored by GinLogger, or the package default
+func LoggerFromGin(c *gin.Context) *Logger {
+	if v, ok := c.Get(ginLoggerKey); ok {
+		if l, ok := v.(*Logger); ok && l != nil {
+			return l
+		}
+	}
+	if c.Request != nil {
+		return LoggerFromContext(c.Request.Context())
+	}
+	return DefaultLogger()
+}
+
+// maxRequestIDLength bounds the caller-supplied request IDs copied into log entries
+const maxRequestIDLength = 128
+
+// requestIDOrNew returns the caller-supplied id when it is safe to log: at most
+// maxRequestIDLength characters among letters, digits, '-', '_', '.' and ':'. Otherwise it
+// generates a new one.
+func requestIDOrNew(id string) string {
+	if id == "" || len(id) > maxRequestIDLength {
+		return newRequestID()
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return newRequestID()
+		}
+	}
+	return id
+}
+
+// newRequestID generates a random 128-bit hex request ID
+func newRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,231 @@
+package observability
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestLoggerFromContext(t *testing.T) {
+	l, _ := newObservedLogger()
+
+	ctx := ContextWithLogger(context.Background(), l)
+	if LoggerFromContext(ctx) != l {
+		t.Error("expected LoggerFromContext to return the stored logger")
+	}
+
+	if LoggerFromContext(context.Background()) != DefaultLogger() {
+		t.Error("expected LoggerFromContext to fall back to the default logger")
+	}
+}
+
+func TestSetDefaultLogger(t *testing.T) {
+	original := DefaultLogger()
+	defer SetDefaultLogger(original)
+
+	l, _ := newObservedLogger()
+	SetDefaultLogger(l)
+
+	if LoggerFromContext(context.Background()) != l {
+		t.Error("expected LoggerFromContext to return the configured default logger")
+	}
+}
+
+func TestDefaultLoggerIsExplicit(t *testing.T) {
+	original := defaultLogger.Load()
+	defer defaultLogger.Store(original)
+	defaultLogger.Store(nil)
+
+	// Building a logger from a config does not make it the default
+	application := NewLogger(&BaseConfig{ServiceName: "application"})
+	fallback := DefaultLogger()
+	if fallback == application {
+		t.Error("expected NewLogger to leave the default logger untouched")
+	}
+	if DefaultLogger() != fallback {
+		t.Error("expected the fallback logger to be built once")
+	}
+
+	SetDefaultLogger(application)
+	if DefaultLogger() != application {
+		t.Error("expected SetDefaultLogger to set the default logger")
+	}
+}
+
+func TestNewRequestID(t *testing.T) {
+	a, b := newRequestID(), newRequestID()
+	if len(a) != 32 {
+		t.Errorf("expected 32 hex chars, got %q", a)
+	}
+	if a == b {
+		t.Error("expected distinct request IDs")
+	}
+}
+
+func TestLoggerFromGin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setupTestTracing(t)
+	base, logs := newObservedLogger()
+
+	router := gin.New()
+	router.Use(GinTracing("test-service"), GinLogger(base))
+	router.GET("/users/:id", func(c *gin.Context) {
+		if LoggerFromGin(c) != LoggerFromContext(c.Request.Context()) {
+			t.Error("expected gin.Context and request context to hold the same logger")
+		}
+		LoggerFromGin(c).Info("loading user")
+		c.Status(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/users/42", nil)
+	req.Header.Set(RequestIDHeader, "req-123")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if got := w.Header().Get(RequestIDHeader); got != "req-123" {
+		t.Errorf("expected request ID to be echoed, got %q", got)
+	}
+
+	handlerLogs := logs.FilterMessage("loading user").All()
+	if len(handlerLogs) != 1 {
+		t.Fatalf("expected 1 handler log entry, got %d", len(handlerLogs))
+	}
+	fields := handlerLogs[0].ContextMap()
+	expected := map[string]string{
+		"method":     http.MethodGet,
+		"route":      "/users/:id",
+		"request_id": "req-123",
+	}
+	for key, want := range expected {
+		if fields[key] != want {
+			t.Errorf("expected %s=%q, got %v", key, want, fields[key])
+		}
+	}
+	if _, ok := fields["trace_id"]; !ok {
+		t.Error("expected trace_id on request-scoped logger")
+	}
+	if _, ok := fields["span_id"]; !ok {
+		t.Error("expected span_id on request-scoped logger")
+	}
+}
+
+func TestRequestIDOrNew(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		keep bool
+	}{
+		{name: "Empty", id: "", keep: false},
+		{name: "UUID", id: "3f2c1e9a-7b4d-4c1e-9f0a-1b2c3d4e5f60", keep: true},
+		{name: "Allowed Punctuation", id: "svc:req_1.2-3", keep: true},
+		{name: "Max Length", id: strings.Repeat("a", maxRequestIDLength), keep: true},
+		{name: "Too Long", id: strings.Repeat("a", maxRequestIDLength+1), keep: false},
+		{name: "Newline", id: "req\nforged=1", keep: false},
+		{name: "Space", id: "req 1", keep: false},
+		{name: "Non ASCII", id: "réq", keep: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := requestIDOrNew(tt.id)
+			if tt.keep && got != tt.id {
+				t.Errorf("expected %q to be kept, got %q", tt.id, got)
+			}
+			if !tt.keep && (got == tt.id || len(got) != 32) {
+				t.Errorf("expected %q to be replaced by a generated ID, got %q", tt.id, got)
+			}
+		})
+	}
+}
+
+func TestLoggerFromGinGeneratesRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	base, _ := newObservedLogger()
+
+	router := gin.New()
+	router.Use(GinLogger(base))
+	router.GET("/ping", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/ping", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if got := w.Header().Get(RequestIDHeader); len(got) != 32 {
+		t.Errorf("expected generated request ID, got %q", got)
+	}
+}
+
+func TestLoggerFromGinWithoutMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+
+	if LoggerFromGin(c) != DefaultLogger() {
+		t.Error("expected LoggerFromGin to fall back to the default logger")
+	}
+}
+
+func TestGrpcRequestScopedLogger(t *testing.T) {
+	base, logs := newObservedLogger()
+
+	md := metadata.Pairs("x-request-id", "grpc-req-1")
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		LoggerFromContext(ctx).Info("handling hello")
+		return &mockResponse{}, nil
+	}
+
+	interceptor := GrpcUnaryServerInterceptor(base)
+	info := &grpc.UnaryServerInfo{FullMethod: "/hello.HelloService/SayHello"}
+	if _, err := interceptor(ctx, &mockRequest{}, info, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	handlerLogs := logs.FilterMessage("handling hello").All()
+	if len(handlerLogs) != 1 {
+		t.Fatalf("expected 1 handler log entry, got %d", len(handlerLogs))
+	}
+	fields := handlerLogs[0].ContextMap()
+	expected := map[string]string{
+		"method":      "/hello.HelloService/SayHello",
+		"rpc_service": "hello.HelloService",
+		"rpc_method":  "SayHello",
+		"request_id":  "grpc-req-1",
+	}
+	for key, want := range expected {
+		if fields[key] != want {
+			t.Errorf("expected %s=%q, got %v", key, want, fields[key])
+		}
+	}
+}
+
+func TestGrpcStreamRequestScopedLogger(t *testing.T) {
+	base, _ := newObservedLogger()
+
+	var scoped *Logger
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		scoped = LoggerFromContext(stream.Context())
+		return nil
+	}
+
+	interceptor := GrpcStreamServerInterceptor(base)
+	info := &grpc.StreamServerInfo{FullMethod: "/hello.HelloService/StreamHello"}
+	stream := &mockServerStream{ctx: context.Background()}
+	if err := interceptor(nil, stream, info, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if scoped == nil || scoped == DefaultLogger() || scoped == base {
+		t.Error("expected stream handler to receive a request-scoped logger")
+	}
+}
@@ -0,0 +1,112 @@
+package observability
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LogLevelPath is the path of the log level endpoint served next to the metrics endpoint
+const LogLevelPath = "/loglevel"
+
+// Level returns the current minimum enabled log level. Loggers not built by NewLogger report
+// the level of their core.
+func (l *Logger) Level() zapcore.Level {
+	if !l.hasAtomicLevel() {
+		return zapcore.LevelOf(l.Desugar().Core())
+	}
+	return l.level.Level()
+}
+
+// SetLevel changes the minimum enabled log level of the logger and every logger derived from it.
+// The change is logged at the higher of the old and new levels, so both let it through. Loggers
+// not built by NewLogger have a fixed level and are left unchanged.
+func (l *Logger) SetLevel(level zapcore.Level) {
+	if !l.hasAtomicLevel() {
+		return
+	}
+	previous := l.level.Level()
+	if previous == level {
+		return
+	}
+
+	l.level.SetLevel(level)
+	// Never log the change at dpanic, panic or fatal level
+	l.Logw(min(max(previous, level), zapcore.ErrorLevel), "Log level changed", "from", previous.String(), "to", level.String())
+}
+
+// hasAtomicLevel reports whether the logger was built with a level that can change at runtime
+func (l *Logger) hasAtomicLevel() bool {
+	return l.level != zap.AtomicLevel{}
+}
+
+// logLevelPayload is the JSON body accepted and returned by the log level handler
+type logLevelPayload struct {
+	Level string `json:"level"`
+}
+
+// LogLevelHandler serves the logger's level over HTTP:
+// GET returns {"level":"info"} and PUT with the same body changes it.
+// A nil logger resolves DefaultLogger on every request. The handler is not authenticated; wrap
+// it with RequireBearerToken when mounting it on your own router.
+func LogLevelHandler(logger *Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l := logger
+		if l == nil {
+			l = DefaultLogger()
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			var payload logLevelPayload
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				writeLogLevelError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+				return
+			}
+			level, err := zapcore.ParseLevel(payload.Level)
+			if err != nil || level > zapcore.ErrorLevel {
+				writeLogLevelError(w, http.StatusBadRequest, fmt.Sprintf("invalid level: %s (must be debug, info, warn or error)", payload.Level))
+				return
+			}
+			if !l.hasAtomicLevel() {
+				writeLogLevelError(w, http.StatusConflict, "the logger level cannot be changed at runtime")
+				return
+			}
+			l.SetLevel(level)
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			writeLogLevelError(w, http.StatusMethodNotAllowed, "only GET and PUT are supported")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(logLevelPayload{Level: l.Level().String()})
+	})
+}
+
+// writeLogLevelError writes a JSON error response for the log level handler
+func writeLogLevelError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+// raiseVerbosity returns the next more verbose level, stopping at debug
+func raiseVerbosity(level zapcore.Level) zapcore.Level {
+	if level <= zapcore.DebugLevel {
+		return zapcore.DebugLevel
+	}
+	return level - 1
+}
+
+// lowerVerbosity returns the next less verbose level, stopping at error
+func lowerVerbosity(level zapcore.Level) zapcore.Level {
+	if level >= zapcore.ErrorLevel {
+		return zapcore.ErrorLevel
+	}
+	return level + 1
+}
@@ -0,0 +1,169 @@
+package observability
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestNewLoggerLevelFromConfig(t *testing.T) {
+	logger := NewLogger(&BaseConfig{LogLevel: "warn"})
+	if logger.Level() != zapcore.WarnLevel {
+		t.Errorf("expected warn level, got %s", logger.Level())
+	}
+
+	if NewLogger(nil).Level() != zapcore.InfoLevel {
+		t.Error("expected info level by default")
+	}
+}
+
+func TestLoggerSetLevel(t *testing.T) {
+	logger, logs := newObservedLogger()
+	child := logger.WithContext(testSpanContext())
+
+	logger.SetLevel(zapcore.WarnLevel)
+
+	if child.Level() != zapcore.WarnLevel {
+		t.Errorf("expected child logger to share the level, got %s", child.Level())
+	}
+	child.Info("filtered")
+	if logs.FilterMessage("filtered").Len() != 0 {
+		t.Error("expected info entries to be filtered after raising the level")
+	}
+
+	changes := logs.FilterMessage("Log level changed").All()
+	if len(changes) != 1 {
+		t.Fatalf("expected the level change to be logged once, got %d", len(changes))
+	}
+	fields := changes[0].ContextMap()
+	if fields["from"] != "debug" || fields["to"] != "warn" {
+		t.Errorf("unexpected level change fields: %v", fields)
+	}
+
+	// Lowering the threshold logs after the change so the entry is visible
+	logger.SetLevel(zapcore.InfoLevel)
+	if logs.FilterMessage("Log level changed").Len() != 2 {
+		t.Error("expected lowering the level to be logged")
+	}
+
+	// Setting the same level is a no-op
+	logger.SetLevel(zapcore.InfoLevel)
+	if logs.FilterMessage("Log level changed").Len() != 2 {
+		t.Error("expected no log entry when the level is unchanged")
+	}
+}
+
+func TestLoggerSetLevelLogsAtHigherLevel(t *testing.T) {
+	logger, logs := newObservedLogger()
+	logger.SetLevel(zapcore.WarnLevel)
+
+	// Both warn and error filter info entries, so the change must be logged at error
+	logger.SetLevel(zapcore.ErrorLevel)
+	logger.SetLevel(zapcore.WarnLevel)
+
+	changes := logs.FilterMessage("Log level changed").All()
+	if len(changes) != 3 {
+		t.Fatalf("expected every change to be logged, got %d", len(changes))
+	}
+	for i, want := range []zapcore.Level{zapcore.WarnLevel, zapcore.ErrorLevel, zapcore.ErrorLevel} {
+		if changes[i].Level != want {
+			t.Errorf("change %d: expected %s, got %s", i, want, changes[i].Level)
+		}
+	}
+}
+
+func TestLoggerWithoutAtomicLevel(t *testing.T) {
+	core, _ := observer.New(zapcore.WarnLevel)
+	logger := &Logger{SugaredLogger: zap.New(core).Sugar()}
+
+	if logger.Level() != zapcore.WarnLevel {
+		t.Errorf("expected the core's level, got %s", logger.Level())
+	}
+	logger.SetLevel(zapcore.DebugLevel)
+	if logger.Level() != zapcore.WarnLevel {
+		t.Errorf("expected the level to stay fixed, got %s", logger.Level())
+	}
+
+	w := httptest.NewRecorder()
+	LogLevelHandler(logger).ServeHTTP(w, httptest.NewRequest(http.MethodPut, LogLevelPath, strings.NewReader(`{"level":"debug"}`)))
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected status %d, got %d", http.StatusConflict, w.Code)
+	}
+}
+
+func TestLogLevelHandler(t *testing.T) {
+	logger, _ := newObservedLogger()
+	logger.SetLevel(zapcore.InfoLevel)
+	handler := LogLevelHandler(logger)
+
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		wantStatus int
+		wantLevel  zapcore.Level
+		wantBody   string
+	}{
+		{"get", http.MethodGet, "", http.StatusOK, zapcore.InfoLevel, `{"level":"info"}`},
+		{"put", http.MethodPut, `{"level":"debug"}`, http.StatusOK, zapcore.DebugLevel, `{"level":"debug"}`},
+		{"put uppercase", http.MethodPut, `{"level":"WARN"}`, http.StatusOK, zapcore.WarnLevel, `{"level":"warn"}`},
+		{"invalid level", http.MethodPut, `{"level":"verbose"}`, http.StatusBadRequest, zapcore.WarnLevel, "invalid level"},
+		{"panic level", http.MethodPut, `{"level":"panic"}`, http.StatusBadRequest, zapcore.WarnLevel, "invalid level"},
+		{"invalid body", http.MethodPut, `level=debug`, http.StatusBadRequest, zapcore.WarnLevel, "invalid request body"},
+		{"unsupported method", http.MethodPost, `{"level":"debug"}`, http.StatusMethodNotAllowed, zapcore.WarnLevel, "only GET and PUT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, LogLevelPath, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("expected body to contain %q, got %q", tt.wantBody, w.Body.String())
+			}
+			if logger.Level() != tt.wantLevel {
+				t.Errorf("expected level %s, got %s", tt.wantLevel, logger.Level())
+			}
+		})
+	}
+}
+
+func TestLogLevelHandlerUsesDefaultLogger(t *testing.T) {
+	original := DefaultLogger()
+	defer SetDefaultLogger(original)
+
+	logger, _ := newObservedLogger()
+	SetDefaultLogger(logger)
+
+	req := httptest.NewRequest(http.MethodPut, LogLevelPath, strings.NewReader(`{"level":"error"}`))
+	w := httptest.NewRecorder()
+	LogLevelHandler(nil).ServeHTTP(w, req)
+
+	if logger.Level() != zapcore.ErrorLevel {
+		t.Errorf("expected default logger level to change, got %s", logger.Level())
+	}
+}
+
+func TestVerbosityStepping(t *testing.T) {
+	if raiseVerbosity(zapcore.InfoLevel) != zapcore.DebugLevel {
+		t.Error("expected info to step down to debug")
+	}
+	if raiseVerbosity(zapcore.DebugLevel) != zapcore.DebugLevel {
+		t.Error("expected debug to be the most verbose level")
+	}
+	if lowerVerbosity(zapcore.InfoLevel) != zapcore.WarnLevel {
+		t.Error("expected info to step up to warn")
+	}
+	if lowerVerbosity(zapcore.ErrorLevel) != zapcore.ErrorLevel {
+		t.Error("expected error to be the least verbose level")
+	}
+}
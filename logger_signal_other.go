@@ -0,0 +1,8 @@
+//go:build !unix
+
+package observability
+
+// WatchLevelSignals is a no-op on platforms without SIGUSR1/SIGUSR2
+func (l *Logger) WatchLevelSignals() (stop func()) {
+	return func() {}
+}
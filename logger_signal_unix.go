@@ -0,0 +1,41 @@
+//go:build unix
+
+package observability
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// WatchLevelSignals adjusts the log level on SIGUSR1 (more verbose) and SIGUSR2 (less verbose)
+// until the returned stop function is called
+func (l *Logger) WatchLevelSignals() (stop func()) {
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, syscall.SIGUSR1, syscall.SIGUSR2)
+
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				if sig == syscall.SIGUSR1 {
+					l.SetLevel(raiseVerbosity(l.Level()))
+				} else {
+					l.SetLevel(lowerVerbosity(l.Level()))
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+//go:build unix
+
+package observability
+
+import (
+	"syscall"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWatchLevelSignals(t *testing.T) {
+	logger, _ := newObservedLogger()
+	logger.SetLevel(zapcore.InfoLevel)
+
+	stop := logger.WatchLevelSignals()
+	defer stop()
+
+	waitForLevel := func(want zapcore.Level) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for logger.Level() != want {
+			if time.Now().After(deadline) {
+				t.Fatalf("expected level %s, got %s", want, logger.Level())
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatalf("failed to send SIGUSR1: %v", err)
+	}
+	waitForLevel(zapcore.DebugLevel)
+
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGUSR2); err != nil {
+		t.Fatalf("failed to send SIGUSR2: %v", err)
+	}
+	waitForLevel(zapcore.InfoLevel)
+}
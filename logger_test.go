@@ -1,9 +1,16 @@
 package observability
 
 import (
+	"context"
 	"os"
 	"os/exec"
 	"testing"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestNewLogger(t *testing.T) {
@@ -96,3 +103,107 @@ func TestLoggerFatalSubprocess(t *testing.T) {
 		t.Fatalf("expected *exec.ExitError, got %T: %v", err, err)
 	}
 }
+
+// newObservedLogger returns a Logger backed by an in-memory zap observer
+func newObservedLogger(baggageKeys ...string) (*Logger, *observer.ObservedLogs) {
+	level := zap.NewAtomicLevelAt(zapcore.DebugLevel)
+	core, logs := observer.New(level)
+	return &Logger{SugaredLogger: zap.New(core).Sugar(), level: level, baggageKeys: baggageKeys}, logs
+}
+
+// testSpanContext returns a context carrying a sampled remote span context
+func testSpanContext() context.Context {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	return trace.ContextWithSpanContext(context.Background(), sc)
+}
+
+func TestLoggerCtxMethods(t *testing.T) {
+	l, logs := newObservedLogger()
+	ctx := testSpanContext()
+
+	l.DebugCtx(ctx, "debug msg", "key", "val")
+	l.InfoCtx(ctx, "info msg", "key", "val")
+	l.WarnCtx(ctx, "warn msg", "key", "val")
+	l.ErrorCtx(ctx, "error msg", "key", "val")
+
+	entries := logs.All()
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 entries, got %d", len(entries))
+	}
+	for _, entry := range entries {
+		fields := entry.ContextMap()
+		if fields["key"] != "val" {
+			t.Errorf("%s: expected key=val, got %v", entry.Message, fields["key"])
+		}
+		if fields["trace_id"] != "4bf92f3577b34da6a3ce929d0e0e4736" {
+			t.Errorf("%s: expected trace_id, got %v", entry.Message, fields["trace_id"])
+		}
+		if fields["span_id"] != "00f067aa0ba902b7" {
+			t.Errorf("%s: expected span_id, got %v", entry.Message, fields["span_id"])
+		}
+		if fields["trace_flags"] != "01" {
+			t.Errorf("%s: expected trace_flags=01, got %v", entry.Message, fields["trace_flags"])
+		}
+	}
+}
+
+func TestLoggerCtxWithoutSpan(t *testing.T) {
+	l, logs := newObservedLogger()
+
+	l.InfoCtx(context.Background(), "no span", "key", "val")
+
+	fields := logs.All()[0].ContextMap()
+	for _, key := range []string{"trace_id", "span_id", "trace_flags"} {
+		if _, ok := fields[key]; ok {
+			t.Errorf("expected no %s field without a span, got %v", key, fields[key])
+		}
+	}
+}
+
+func TestLoggerWithContext(t *testing.T) {
+	l, logs := newObservedLogger("tenant", "user_id")
+
+	tenant, _ := baggage.NewMember("tenant", "acme")
+	region, _ := baggage.NewMember("region", "eu")
+	bag, _ := baggage.New(tenant, region)
+	ctx := baggage.ContextWithBaggage(testSpanContext(), bag)
+
+	child := l.WithContext(ctx)
+	if child == l {
+		t.Fatal("expected WithContext to return a child logger")
+	}
+	child.Info("child msg")
+
+	fields := logs.All()[0].ContextMap()
+	if fields["trace_id"] != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected trace_id on child logger, got %v", fields["trace_id"])
+	}
+	if fields["baggage.tenant"] != "acme" {
+		t.Errorf("expected baggage.tenant=acme, got %v", fields["baggage.tenant"])
+	}
+	if _, ok := fields["baggage.region"]; ok {
+		t.Error("expected unselected baggage member to be omitted")
+	}
+	if _, ok := fields["baggage.user_id"]; ok {
+		t.Error("expected missing baggage member to be omitted")
+	}
+
+	// A context without trace data or baggage returns the same logger
+	if l.WithContext(context.Background()) != l {
+		t.Error("expected WithContext to return the receiver for an empty context")
+	}
+}
+
+func TestNewLoggerBaggageKeys(t *testing.T) {
+	l := NewLogger(&BaseConfig{ServiceName: "test-baggage", LogBaggageKeys: []string{" tenant ", ""}})
+	if len(l.baggageKeys) != 1 || l.baggageKeys[0] != "tenant" {
+		t.Errorf("expected trimmed baggage keys [tenant], got %v", l.baggageKeys)
+	}
+}
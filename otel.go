@@ -8,75 +8,165 @@ import (
 	"strings"
 	"time"
 
-	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/propagators/autoprop"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/log/global"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
-	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
-	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
-// InitOtel initializes OpenTelemetry with support for Tracing (Push)
-// and Metrics (Pull/Push/Hybrid)
+// InitOtel initializes OpenTelemetry with support for Tracing (Push),
+// Metrics (Pull/Push/Hybrid) and Logs (OTLP, when LogsMode enables it)
 func InitOtel(cfg BaseConfig) (func(context.Context) error, error) {
+	tel, err := InitOtelWithOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return tel.Shutdown, nil
+}
+
+// InitOtelWithOptions is InitOtel with components supplied by the caller (exporters, processors,
+// samplers, readers, views, propagator, resource or the internal-error logger); see Option.
+// It returns a Telemetry handle exposing the providers it created.
+func InitOtelWithOptions(cfg BaseConfig, options ...Option) (*Telemetry, error) {
 	ctx := context.Background()
+	o := newOtelOptions(options)
 
 	// 1. Initialize Resource identifying the service
-	res, err := resource.New(ctx,
-		resource.WithAttributes(
-			semconv.ServiceName(cfg.ServiceName),
-			semconv.ServiceVersion(cfg.Version),
-		),
-	)
+	var err error
+	res := o.resource
+	if res == nil {
+		if res, err = newResource(ctx, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	// Resolve TLS and header settings shared by every OTLP exporter
+	exporter, err := newExporterSettings(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create resource: %w", err)
+		return nil, err
 	}
 
-	// 2. Configure Tracing (Push model sending to Otel Collector)
-	traceExp, err := otlptracehttp.New(ctx,
-		otlptracehttp.WithEndpoint(cfg.OtelEndpoint),
-		otlptracehttp.WithInsecure(),
-	)
+	propagator := o.propagator
+	if propagator == nil {
+		if propagator, err = newTextMapPropagator(cfg.Propagators); err != nil {
+			return nil, fmt.Errorf("invalid propagators: %w", err)
+		}
+	}
+
+	sampler := o.sampler
+	if sampler == nil {
+		if sampler, err = newTraceSampler(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	// Views from METRICS_VIEWS_FILE/METRICS_VIEWS, then those passed with WithView
+	views, err := newConfigViews(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+		return nil, err
 	}
+	views = append(views, o.views...)
 
-	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithSampler(sdktrace.TraceIDRatioBased(cfg.OtelTracingSampleRate)),
-		sdktrace.WithResource(res),
-		sdktrace.WithSpanProcessor(sdktrace.NewBatchSpanProcessor(traceExp)),
+	// 2. Configure Tracing (Push model sending to Otel Collector over HTTP or gRPC)
+	traceExp := o.spanExporter
+	if traceExp == nil {
+		switch strings.ToLower(strings.TrimSpace(cfg.TracesProtocol)) {
+		case "grpc":
+			traceExp, err = otlptracegrpc.New(ctx, exporter.traceGRPCOptions(cfg.OtelEndpoint)...)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create OTLP gRPC trace exporter: %w", err)
+			}
+		default:
+			// Default to HTTP if protocol not specified
+			traceExp, err = otlptracehttp.New(ctx, exporter.traceHTTPOptions(cfg.OtelEndpoint)...)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+			}
+		}
+	}
+
+	var (
+		spanProcessor sdktrace.SpanProcessor = sdktrace.NewBatchSpanProcessor(traceExp)
+		tail          *tailSamplingProcessor
 	)
-	otel.SetTracerProvider(tp)
+	if cfg.TailSamplingEnabled {
+		// Keep failed and slow traces, ratio-sample the rest before batching
+		tail = newTailSamplingProcessor(spanProcessor, cfg)
+		spanProcessor = tail
+	}
+
+	tpOpts := []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(sampler),
+		sdktrace.WithResource(res),
+		sdktrace.WithSpanProcessor(spanProcessor),
+	}
+	for _, processor := range o.spanProcessors {
+		tpOpts = append(tpOpts, sdktrace.WithSpanProcessor(processor))
+	}
+	tp := sdktrace.NewTracerProvider(tpOpts...)
+
+	// The handle collects what is started from here on; if a later step fails, it is shut down
+	// so no goroutine leaks and the metrics port is released for a retry
+	tel := &Telemetry{tracerProvider: tp, propagator: propagator}
+	initialized := false
+	defer func() {
+		if !initialized {
+			_ = tel.Shutdown(ctx)
+		}
+	}()
 
 	// 3. Configure Metrics based on MetricsMode
 	var (
-		mp              *sdkmetric.MeterProvider
-		metricsServer   *http.Server
-		metricsShutdown []func(context.Context) error
-		readers         []sdkmetric.Reader
+		mp      *sdkmetric.MeterProvider
+		readers []sdkmetric.Reader
 	)
+	// Runtime histograms are produced at collection time by every built-in reader
+	var (
+		promOpts     []prometheus.Option
+		periodicOpts []sdkmetric.PeriodicReaderOption
+	)
+	if producer := newRuntimeProducer(cfg); producer != nil {
+		promOpts = append(promOpts, prometheus.WithProducer(producer))
+		periodicOpts = append(periodicOpts, sdkmetric.WithProducer(producer))
+	}
+	// The exporter and the pull server share a private registry, so several instances can
+	// coexist and collectors registered on the default registry stay off the endpoint
+	registry, err := newPrometheusRegistry(o.promCollectors)
+	if err != nil {
+		return nil, err
+	}
+	promOpts = append(promOpts, prometheus.WithRegisterer(registry))
+	// Overflow series are counted as the built-in readers collect them
+	overflow := newCardinalityOverflow(cfg)
+	// Checks behind /livez, /readyz and the health_check_status gauge
+	health := o.health
+	if health == nil {
+		health = NewHealthRegistry()
+	}
 
 	// Setup metrics exporter(s) based on mode
 	if cfg.IsPull() {
 		// Pull mode: Prometheus exporter
-		promExporter, err := prometheus.New()
+		promExporter, err := prometheus.New(promOpts...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create prometheus exporter: %w", err)
 		}
 		readers = append(readers, promExporter)
 
 		// Setup HTTP server for pull metrics
-		mux := http.NewServeMux()
-		mux.Handle(cfg.MetricsPath, promhttp.Handler())
+		mux := newMetricsMux(cfg, o, newPrometheusHandler(registry, overflow.gatherer(registry)), health)
 
-		metricsServer = &http.Server{
+		metricsServer := &http.Server{
 			Addr:    fmt.Sprintf("0.0.0.0:%d", cfg.MetricsPort),
 			Handler: mux,
 		}
@@ -87,10 +177,11 @@ func InitOtel(cfg BaseConfig) (func(context.Context) error, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to bind metrics server addr %s: %w", metricsServer.Addr, err)
 		}
+		tel.metricsServer, tel.metricsAddr = metricsServer, ln.Addr().String()
 
 		go func() {
 			if err := metricsServer.Serve(ln); err != nil && err != http.ErrServerClosed {
-				fmt.Printf("Metrics server error: %v\n", err)
+				o.logError("Metrics server error", err)
 			}
 		}()
 	}
@@ -105,52 +196,48 @@ func InitOtel(cfg BaseConfig) (func(context.Context) error, error) {
 		switch protocol {
 		case "grpc":
 			// Use gRPC protocol for OTLP metrics export
-			exp, err := otlpmetricgrpc.New(ctx,
-				otlpmetricgrpc.WithEndpoint(cfg.MetricsPushEndpoint),
-				otlpmetricgrpc.WithInsecure(),
-			)
+			exp, err := otlpmetricgrpc.New(ctx, exporter.metricGRPCOptions(cfg.MetricsPushEndpoint)...)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create OTLP gRPC metrics exporter: %w", err)
 			}
 			
-			reader := sdkmetric.NewPeriodicReader(exp,
-				sdkmetric.WithInterval(pushInterval),
+			reader := sdkmetric.NewPeriodicReader(overflow.exporter(exp),
+				append(periodicOpts, sdkmetric.WithInterval(pushInterval))...,
 			)
-			metricsShutdown = append(metricsShutdown, reader.Shutdown)
+			tel.metricsShutdown = append(tel.metricsShutdown, reader.Shutdown)
 			readers = append(readers, reader)
 		case "http":
 			// Use HTTP protocol for OTLP metrics export
 			fallthrough
 		default:
 			// Default to HTTP if protocol not specified
-			exp, err := otlpmetrichttp.New(ctx,
-				otlpmetrichttp.WithEndpoint(cfg.MetricsPushEndpoint),
-				otlpmetrichttp.WithInsecure(),
-			)
+			exp, err := otlpmetrichttp.New(ctx, exporter.metricHTTPOptions(cfg.MetricsPushEndpoint)...)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create OTLP HTTP metrics exporter: %w", err)
 			}
 			
-			reader := sdkmetric.NewPeriodicReader(exp,
-				sdkmetric.WithInterval(pushInterval),
+			reader := sdkmetric.NewPeriodicReader(overflow.exporter(exp),
+				append(periodicOpts, sdkmetric.WithInterval(pushInterval))...,
 			)
-			metricsShutdown = append(metricsShutdown, reader.Shutdown)
+			tel.metricsShutdown = append(tel.metricsShutdown, reader.Shutdown)
 			readers = append(readers, reader)
 		}
 	}
 
+	// Readers supplied through WithMetricReader
+	readers = append(readers, o.metricReaders...)
+
 	// If no readers configured, default to pull mode
 	if len(readers) == 0 {
-		promExporter, err := prometheus.New()
+		promExporter, err := prometheus.New(promOpts...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create prometheus exporter: %w", err)
 		}
 		readers = append(readers, promExporter)
 
-		mux := http.NewServeMux()
-		mux.Handle(cfg.MetricsPath, promhttp.Handler())
+		mux := newMetricsMux(cfg, o, newPrometheusHandler(registry, overflow.gatherer(registry)), health)
 
-		metricsServer = &http.Server{
+		metricsServer := &http.Server{
 			Addr:    fmt.Sprintf("0.0.0.0:%d", cfg.MetricsPort),
 			Handler: mux,
 		}
@@ -161,10 +248,11 @@ func InitOtel(cfg BaseConfig) (func(context.Context) error, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to bind metrics server addr %s: %w", metricsServer.Addr, err)
 		}
+		tel.metricsServer, tel.metricsAddr = metricsServer, ln.Addr().String()
 
 		go func() {
 			if err := metricsServer.Serve(ln); err != nil && err != http.ErrServerClosed {
-				fmt.Printf("Metrics server error: %v\n", err)
+				o.logError("Metrics server error", err)
 			}
 		}()
 	}
@@ -176,59 +264,78 @@ func InitOtel(cfg BaseConfig) (func(context.Context) error, error) {
 	for _, r := range readers {
 		opts = append(opts, sdkmetric.WithReader(r))
 	}
+	if len(views) > 0 {
+		opts = append(opts, sdkmetric.WithView(views...))
+	}
+	if cfg.MetricsCardinalityLimit > 0 {
+		// Collapse attribute sets beyond the limit into the otel.metric.overflow series
+		opts = append(opts, sdkmetric.WithCardinalityLimit(cfg.MetricsCardinalityLimit))
+	}
 	mp = sdkmetric.NewMeterProvider(opts...)
-	otel.SetMeterProvider(mp)
-
-	// 4. Configure Global Propagator (W3C Trace Context & Baggage)
-	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
-		propagation.TraceContext{},
-		propagation.Baggage{},
-	))
-
-	// Return a Shutdown function to clean up resources when service stops
-	return func(ctx context.Context) error {
-		var errs []string
-
-		// ForceFlush Meter Provider to ensure all metrics are sent before shutdown
-		// This is especially important for short-lived jobs and batch processes
-		if err := mp.ForceFlush(ctx); err != nil {
-			errs = append(errs, fmt.Sprintf("meter provider force flush error: %v", err))
-		}
+	tel.meterProvider = mp
+	if overflow != nil {
+		overflow.useMeterProvider(mp)
+	}
+	health.useMeterProvider(mp)
+	tel.metricsShutdown = append(tel.metricsShutdown, health.refresh())
+	startRuntimeMetrics(cfg, mp)
+	buildInfo := NewBuildInfo(cfg)
+	registerBuildInfoMetric(buildInfo, mp)
+	if tail != nil {
+		// Count tail sampling drops on this service's MeterProvider
+		tail.useMeterProvider(mp)
+	}
 
-		// ForceFlush Tracer Provider to ensure all traces are sent before shutdown
-		if err := tp.ForceFlush(ctx); err != nil {
-			errs = append(errs, fmt.Sprintf("tracer provider force flush error: %v", err))
+	// 4. Configure Logs export (OTLP) when enabled
+	var lp *sdklog.LoggerProvider
+	if cfg.IsLogsOTLP() {
+		lp, err = newLoggerProvider(ctx, cfg, exporter, res)
+		if err != nil {
+			return nil, err
 		}
+		tel.loggerProvider = lp
+	}
 
-		// Shutdown Metrics Server (if pull mode enabled)
-		if metricsServer != nil {
-			if err := metricsServer.Shutdown(ctx); err != nil {
-				errs = append(errs, fmt.Sprintf("metrics server shutdown error: %v", err))
-			}
+	// 5. Install the global providers and propagator (W3C Trace Context & Baggage unless
+	// OTEL_PROPAGATORS says otherwise), unless the caller keeps this instance isolated
+	if !o.skipGlobals {
+		if handler := o.errorHandler(); handler != nil {
+			otel.SetErrorHandler(handler)
 		}
-
-		// Shutdown Tracer Provider
-		if err := tp.Shutdown(ctx); err != nil {
-			errs = append(errs, fmt.Sprintf("tracer provider shutdown error: %v", err))
+		otel.SetTracerProvider(tp)
+		otel.SetMeterProvider(mp)
+		if lp != nil {
+			global.SetLoggerProvider(lp)
 		}
+		otel.SetTextMapPropagator(propagator)
+	}
 
-		// Shutdown Meter Provider
-		if err := mp.Shutdown(ctx); err != nil {
-			errs = append(errs, fmt.Sprintf("meter provider shutdown error: %v", err))
-		}
+	// 6. Log the startup event with the build information and the effective configuration
+	startupLogger := o.logger
+	if startupLogger == nil {
+		startupLogger = DefaultLogger()
+	}
+	logStartup(startupLogger, cfg, buildInfo)
 
-		// Shutdown push-specific resources
-		for _, shutdown := range metricsShutdown {
-			if err := shutdown(ctx); err != nil {
-				errs = append(errs, fmt.Sprintf("push metrics shutdown error: %v", err))
-			}
-		}
+	tel.health, tel.promRegistry = health, registry
+	initialized = true
+	return tel, nil
+}
 
-		if len(errs) > 0 {
-			return fmt.Errorf("otel shutdown failures: %s", strings.Join(errs, "; "))
-		}
-		return nil
-	}, nil
+// newMetricsMux builds the routes served by the internal metrics server
+func newMetricsMux(cfg BaseConfig, o *otelOptions, metrics http.Handler, health *HealthRegistry) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle(cfg.MetricsPath, metrics)
+	if cfg.LogLevelEndpointEnabled {
+		// Controls WithLogger's logger, else DefaultLogger
+		mux.Handle(LogLevelPath, RequireBearerToken(cfg.DebugEndpointsToken, LogLevelHandler(o.logger)))
+	}
+	mux.Handle(LivenessPath, health.LivenessHandler())
+	mux.Handle(ReadinessPath, health.ReadinessHandler())
+	if cfg.DebugEndpointsEnabled {
+		mountDebugEndpoints(mux, cfg)
+	}
+	return mux
 }
 
 // GetTracer returns a tracer instance
@@ -240,3 +347,22 @@ func GetTracer(name string) trace.Tracer {
 func GetMeter(name string) metric.Meter {
 	return otel.Meter(name)
 }
+
+// newTextMapPropagator builds the propagator for the given OTEL_PROPAGATORS names
+// ("tracecontext", "baggage", "b3", "b3multi", "jaeger", "xray", "ottrace" or "none"),
+// defaulting to W3C Trace Context and Baggage
+func newTextMapPropagator(names []string) (propagation.TextMapPropagator, error) {
+	var trimmed []string
+	for _, name := range names {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			trimmed = append(trimmed, name)
+		}
+	}
+	if len(trimmed) == 0 {
+		return propagation.NewCompositeTextMapPropagator(
+			propagation.TraceContext{},
+			propagation.Baggage{},
+		), nil
+	}
+	return autoprop.TextMapPropagator(trimmed...)
+}
@@ -0,0 +1,151 @@
+package observability
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// metricOverflowKey marks the series the SDK aggregates measurements into once an instrument
+// reaches the cardinality limit
+const metricOverflowKey = attribute.Key("otel.metric.overflow")
+
+// cardinalityOverflow counts, per instrument, the otel.metric.overflow series seen while the
+// built-in metrics readers collect: on every Prometheus scrape and every OTLP push
+type cardinalityOverflow struct {
+	mu sync.Mutex
+	// series maps an instrument name to the number of overflow series collected for it
+	series map[string]int64
+}
+
+// newCardinalityOverflow returns nil when cfg sets no cardinality limit, since nothing can
+// overflow then
+func newCardinalityOverflow(cfg BaseConfig) *cardinalityOverflow {
+	if cfg.MetricsCardinalityLimit <= 0 {
+		return nil
+	}
+	return &cardinalityOverflow{series: map[string]int64{}}
+}
+
+// useMeterProvider exports the counts as the metrics.cardinality.overflow counter
+// (metrics_cardinality_overflow_total in Prometheus)
+func (c *cardinalityOverflow) useMeterProvider(mp metric.MeterProvider) {
+	_, err := mp.Meter("metrics-cardinality").Int64ObservableCounter(
+		"metrics.cardinality.overflow",
+		metric.WithDescription("Number of otel.metric.overflow series collected per instrument"),
+		metric.WithUnit("{series}"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			for instrument, n := range c.series {
+				o.Observe(n, metric.WithAttributes(attribute.String("instrument", instrument)))
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+}
+
+// count records one overflow series collected for instrument
+func (c *cardinalityOverflow) count(instrument string) {
+	c.mu.Lock()
+	c.series[instrument]++
+	c.mu.Unlock()
+}
+
+// exporter wraps exp so every export counts the overflow series it carries; it returns exp
+// unchanged when c is nil
+func (c *cardinalityOverflow) exporter(exp sdkmetric.Exporter) sdkmetric.Exporter {
+	if c == nil {
+		return exp
+	}
+	return &overflowExporter{Exporter: exp, overflow: c}
+}
+
+// gatherer wraps g so every scrape counts the overflow series it returns, keyed by Prometheus
+// family name; it returns g unchanged when c is nil
+func (c *cardinalityOverflow) gatherer(g prometheus.Gatherer) prometheus.Gatherer {
+	if c == nil {
+		return g
+	}
+	return prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		families, err := g.Gather()
+		for _, family := range families {
+			for _, m := range family.GetMetric() {
+				if slices.ContainsFunc(m.GetLabel(), isOverflowLabel) {
+					c.count(family.GetName())
+				}
+			}
+		}
+		return families, err
+	})
+}
+
+// isOverflowLabel reports whether label is otel_metric_overflow="true", the Prometheus form of
+// otel.metric.overflow=true
+func isOverflowLabel(label *dto.LabelPair) bool {
+	return label.GetName() == "otel_metric_overflow" && label.GetValue() == "true"
+}
+
+// overflowExporter counts the overflow series of every export before handing it to Exporter
+type overflowExporter struct {
+	sdkmetric.Exporter
+	overflow *cardinalityOverflow
+}
+
+// Export implements sdkmetric.Exporter
+func (e *overflowExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if hasOverflowDataPoint(m.Data) {
+				e.overflow.count(m.Name)
+			}
+		}
+	}
+	return e.Exporter.Export(ctx, rm)
+}
+
+// hasOverflowDataPoint reports whether any data point carries otel.metric.overflow=true
+func hasOverflowDataPoint(data metricdata.Aggregation) bool {
+	switch d := data.(type) {
+	case metricdata.Sum[int64]:
+		return anyOverflow(d.DataPoints, func(dp metricdata.DataPoint[int64]) attribute.Set { return dp.Attributes })
+	case metricdata.Sum[float64]:
+		return anyOverflow(d.DataPoints, func(dp metricdata.DataPoint[float64]) attribute.Set { return dp.Attributes })
+	case metricdata.Gauge[int64]:
+		return anyOverflow(d.DataPoints, func(dp metricdata.DataPoint[int64]) attribute.Set { return dp.Attributes })
+	case metricdata.Gauge[float64]:
+		return anyOverflow(d.DataPoints, func(dp metricdata.DataPoint[float64]) attribute.Set { return dp.Attributes })
+	case metricdata.Histogram[int64]:
+		return anyOverflow(d.DataPoints, func(dp metricdata.HistogramDataPoint[int64]) attribute.Set { return dp.Attributes })
+	case metricdata.Histogram[float64]:
+		return anyOverflow(d.DataPoints, func(dp metricdata.HistogramDataPoint[float64]) attribute.Set { return dp.Attributes })
+	case metricdata.ExponentialHistogram[int64]:
+		return anyOverflow(d.DataPoints, func(dp metricdata.ExponentialHistogramDataPoint[int64]) attribute.Set { return dp.Attributes })
+	case metricdata.ExponentialHistogram[float64]:
+		return anyOverflow(d.DataPoints, func(dp metricdata.ExponentialHistogramDataPoint[float64]) attribute.Set { return dp.Attributes })
+	default:
+		return false
+	}
+}
+
+// anyOverflow reports whether any data point's attributes carry otel.metric.overflow=true
+func anyOverflow[DP any](dps []DP, attrs func(DP) attribute.Set) bool {
+	for _, dp := range dps {
+		set := attrs(dp)
+		if v, ok := set.Value(metricOverflowKey); ok && v.AsBool() {
+			return true
+		}
+	}
+	return false
+}
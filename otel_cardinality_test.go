@@ -0,0 +1,253 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// overflowSeries returns the metrics.cardinality.overflow count observed for instrument
+func overflowSeries(t *testing.T, reader *sdkmetric.ManualReader, instrument string) (int64, bool) {
+	t.Helper()
+	m, ok := findMetric(t, reader, "metrics.cardinality.overflow")
+	if !ok {
+		return 0, false
+	}
+	sum, ok := m.Data.(metricdata.Sum[int64])
+	if !ok {
+		t.Fatalf("expected an int64 sum, got %T", m.Data)
+	}
+	for _, dp := range sum.DataPoints {
+		if v, ok := dp.Attributes.Value("instrument"); ok && v.AsString() == instrument {
+			return dp.Value, true
+		}
+	}
+	return 0, false
+}
+
+// countingExporter is a metrics exporter that only counts its exports
+type countingExporter struct{ exports int }
+
+func (e *countingExporter) Temporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	return sdkmetric.DefaultTemporalitySelector(kind)
+}
+func (e *countingExporter) Aggregation(kind sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	return sdkmetric.DefaultAggregationSelector(kind)
+}
+func (e *countingExporter) Export(context.Context, *metricdata.ResourceMetrics) error {
+	e.exports++
+	return nil
+}
+func (e *countingExporter) ForceFlush(context.Context) error { return nil }
+func (e *countingExporter) Shutdown(context.Context) error   { return nil }
+
+func TestHasOverflowDataPoint(t *testing.T) {
+	overflowAttrs := attribute.NewSet(metricOverflowKey.Bool(true))
+	plainAttrs := attribute.NewSet(attribute.String("route", "/a"))
+
+	tests := []struct {
+		name string
+		data metricdata.Aggregation
+		want bool
+	}{
+		{
+			name: "Sum With Overflow",
+			data: metricdata.Sum[int64]{DataPoints: []metricdata.DataPoint[int64]{{Attributes: plainAttrs}, {Attributes: overflowAttrs}}},
+			want: true,
+		},
+		{
+			name: "Sum Without Overflow",
+			data: metricdata.Sum[int64]{DataPoints: []metricdata.DataPoint[int64]{{Attributes: plainAttrs}}},
+			want: false,
+		},
+		{
+			name: "Gauge With Overflow",
+			data: metricdata.Gauge[float64]{DataPoints: []metricdata.DataPoint[float64]{{Attributes: overflowAttrs}}},
+			want: true,
+		},
+		{
+			name: "Histogram With Overflow",
+			data: metricdata.Histogram[float64]{DataPoints: []metricdata.HistogramDataPoint[float64]{{Attributes: overflowAttrs}}},
+			want: true,
+		},
+		{
+			name: "Exponential Histogram With Overflow",
+			data: metricdata.ExponentialHistogram[int64]{DataPoints: []metricdata.ExponentialHistogramDataPoint[int64]{{Attributes: overflowAttrs}}},
+			want: true,
+		},
+		{
+			name: "Overflow False",
+			data: metricdata.Sum[float64]{DataPoints: []metricdata.DataPoint[float64]{{Attributes: attribute.NewSet(metricOverflowKey.Bool(false))}}},
+			want: false,
+		},
+		{name: "Nil", data: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasOverflowDataPoint(tt.data); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCardinalityOverflow(t *testing.T) {
+	overflow := newCardinalityOverflow(BaseConfig{MetricsCardinalityLimit: 4})
+	exporter := &countingExporter{}
+	push := sdkmetric.NewPeriodicReader(overflow.exporter(exporter))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(reader),
+		sdkmetric.WithReader(push),
+		// 3 attribute sets plus the overflow series
+		sdkmetric.WithCardinalityLimit(4),
+	)
+	overflow.useMeterProvider(mp)
+	defer func() { _ = mp.Shutdown(context.Background()) }()
+
+	meter := mp.Meter("cardinality-test")
+	requests, _ := meter.Int64Counter("requests")
+	latency, _ := meter.Float64Histogram("latency")
+	errs, _ := meter.Int64Counter("errors")
+	for i := range 5 {
+		attrs := metric.WithAttributes(attribute.String("user.id", fmt.Sprint(i)))
+		requests.Add(context.Background(), 1, attrs)
+		latency.Record(context.Background(), 0.1, attrs)
+	}
+	errs.Add(context.Background(), 1)
+
+	if _, ok := overflowSeries(t, reader, "requests"); ok {
+		t.Error("expected no count before the first export")
+	}
+
+	// Every export counts the overflow series it carries
+	for range 2 {
+		if err := push.ForceFlush(context.Background()); err != nil {
+			t.Fatalf("ForceFlush failed: %v", err)
+		}
+	}
+	if exporter.exports != 2 {
+		t.Errorf("expected 2 exports to reach the wrapped exporter, got %d", exporter.exports)
+	}
+
+	// Keyed by the instrument name, whatever the exporter calls it
+	for _, instrument := range []string{"requests", "latency"} {
+		if got, ok := overflowSeries(t, reader, instrument); !ok || got != 2 {
+			t.Errorf("%s: expected 2, got %d (found=%v)", instrument, got, ok)
+		}
+	}
+	if _, ok := overflowSeries(t, reader, "errors"); ok {
+		t.Error("expected no count for an instrument below the limit")
+	}
+}
+
+func TestCardinalityOverflow_NoLimit(t *testing.T) {
+	overflow := newCardinalityOverflow(BaseConfig{})
+	if overflow != nil {
+		t.Fatal("expected no overflow counting without a cardinality limit")
+	}
+
+	exporter := &countingExporter{}
+	if overflow.exporter(exporter) != exporter {
+		t.Error("expected the exporter to be left unwrapped")
+	}
+	registry := prometheus.NewRegistry()
+	if overflow.gatherer(registry) != registry {
+		t.Error("expected the gatherer to be left unwrapped")
+	}
+}
+
+func TestInitOtel_CardinalityLimit(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	cfg := BaseConfig{ServiceName: "cardinality-service", MetricsCardinalityLimit: 3}
+
+	tel, err := InitOtelWithOptions(cfg,
+		WithoutGlobals(),
+		WithSpanExporter(tracetest.NewInMemoryExporter()),
+		WithMetricReader(reader),
+	)
+	if err != nil {
+		t.Fatalf("InitOtelWithOptions failed: %v", err)
+	}
+	defer func() { _ = tel.Shutdown(context.Background()) }()
+
+	requests, _ := tel.MeterProvider().Meter("cardinality-test").Int64Counter("requests")
+	for i := range 10 {
+		requests.Add(context.Background(), 1, metric.WithAttributes(attribute.String("user.id", fmt.Sprint(i))))
+	}
+
+	m, ok := findMetric(t, reader, "requests")
+	if !ok {
+		t.Fatal("requests not found")
+	}
+	sum, ok := m.Data.(metricdata.Sum[int64])
+	if !ok {
+		t.Fatalf("expected an int64 sum, got %T", m.Data)
+	}
+	if got := len(sum.DataPoints); got != 3 {
+		t.Errorf("expected the limit of 3 series, got %d", got)
+	}
+	var total int64
+	for _, dp := range sum.DataPoints {
+		total += dp.Value
+	}
+	if total != 10 {
+		t.Errorf("expected no measurement to be lost, got a total of %d", total)
+	}
+	if !hasOverflowDataPoint(m.Data) {
+		t.Error("expected the otel.metric.overflow series")
+	}
+}
+
+func TestInitOtel_CardinalityOverflowScrape(t *testing.T) {
+	cfg := BaseConfig{
+		ServiceName:             "cardinality-pull-service",
+		MetricsMode:             "pull",
+		MetricsPath:             "/metrics",
+		MetricsCardinalityLimit: 2,
+	}
+
+	tel, err := InitOtelWithOptions(cfg, WithoutGlobals(), WithSpanExporter(tracetest.NewInMemoryExporter()))
+	if err != nil {
+		t.Fatalf("InitOtelWithOptions failed: %v", err)
+	}
+	defer func() { _ = tel.Shutdown(context.Background()) }()
+
+	requests, _ := tel.MeterProvider().Meter("cardinality-test").Int64Counter("cardinality_scrape_requests")
+	for i := range 5 {
+		requests.Add(context.Background(), 1, metric.WithAttributes(attribute.String("user.id", fmt.Sprint(i))))
+	}
+
+	scrape := func() string {
+		t.Helper()
+		resp, err := http.Get("http://" + tel.MetricsAddr() + "/metrics")
+		if err != nil {
+			t.Fatalf("failed to scrape metrics: %v", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("failed to read metrics: %v", err)
+		}
+		return string(body)
+	}
+
+	// A scrape reports the overflow series counted by the previous ones, keyed by the
+	// Prometheus family name
+	scrape()
+	overflowing := regexp.MustCompile(`metrics_cardinality_overflow_total\{[^}]*instrument="cardinality_scrape_requests_total"[^}]*\} 1\n`)
+	if body := scrape(); !overflowing.MatchString(body) {
+		t.Errorf("expected one overflow series counted for the instrument:\n%s", body)
+	}
+}
@@ -0,0 +1,183 @@
+package observability
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"google.golang.org/grpc/credentials"
+)
+
+// exporterSettings holds the transport settings shared by every OTLP exporter
+type exporterSettings struct {
+	// tlsConfig is nil when TLS is disabled and the exporters connect insecurely
+	tlsConfig *tls.Config
+	headers   map[string]string
+	// gzip enables gzip compression of export requests
+	gzip bool
+}
+
+// newExporterSettings builds the OTLP exporter transport settings from the config
+func newExporterSettings(cfg BaseConfig) (exporterSettings, error) {
+	headers, err := cfg.ExporterHeaders()
+	if err != nil {
+		return exporterSettings{}, err
+	}
+
+	tlsConfig, err := exporterTLSConfig(cfg)
+	if err != nil {
+		return exporterSettings{}, err
+	}
+
+	return exporterSettings{
+		tlsConfig: tlsConfig,
+		headers:   headers,
+		gzip:      strings.EqualFold(strings.TrimSpace(cfg.OtelExporterCompression), "gzip"),
+	}, nil
+}
+
+// exporterTLSConfig builds the client TLS configuration, or returns nil when TLS is disabled
+func exporterTLSConfig(cfg BaseConfig) (*tls.Config, error) {
+	if !cfg.OtelExporterTLS {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: cfg.OtelExporterServerName,
+	}
+
+	// Trust a custom CA bundle instead of the system roots when configured
+	if cfg.OtelExporterCAFile != "" {
+		pem, err := os.ReadFile(cfg.OtelExporterCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read exporter CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("failed to parse exporter CA file %s: no PEM certificates found", cfg.OtelExporterCAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	// Present a client certificate for mTLS when configured
+	if cfg.OtelExporterCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.OtelExporterCertFile, cfg.OtelExporterKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load exporter client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// traceHTTPOptions returns the OTLP/HTTP trace exporter options for endpoint
+func (s exporterSettings) traceHTTPOptions(endpoint string) []otlptracehttp.Option {
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(endpoint)}
+	if s.tlsConfig != nil {
+		opts = append(opts, otlptracehttp.WithTLSClientConfig(s.tlsConfig))
+	} else {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	if len(s.headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(s.headers))
+	}
+	if s.gzip {
+		opts = append(opts, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+	}
+	return opts
+}
+
+// traceGRPCOptions returns the OTLP/gRPC trace exporter options for endpoint
+func (s exporterSettings) traceGRPCOptions(endpoint string) []otlptracegrpc.Option {
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(endpoint)}
+	if s.tlsConfig != nil {
+		opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(s.tlsConfig)))
+	} else {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	if len(s.headers) > 0 {
+		opts = append(opts, otlptracegrpc.WithHeaders(s.headers))
+	}
+	if s.gzip {
+		opts = append(opts, otlptracegrpc.WithCompressor("gzip"))
+	}
+	return opts
+}
+
+// metricHTTPOptions returns the OTLP/HTTP metric exporter options for endpoint
+func (s exporterSettings) metricHTTPOptions(endpoint string) []otlpmetrichttp.Option {
+	opts := []otlpmetrichttp.Option{otlpmetrichttp.WithEndpoint(endpoint)}
+	if s.tlsConfig != nil {
+		opts = append(opts, otlpmetrichttp.WithTLSClientConfig(s.tlsConfig))
+	} else {
+		opts = append(opts, otlpmetrichttp.WithInsecure())
+	}
+	if len(s.headers) > 0 {
+		opts = append(opts, otlpmetrichttp.WithHeaders(s.headers))
+	}
+	if s.gzip {
+		opts = append(opts, otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression))
+	}
+	return opts
+}
+
+// metricGRPCOptions returns the OTLP/gRPC metric exporter options for endpoint
+func (s exporterSettings) metricGRPCOptions(endpoint string) []otlpmetricgrpc.Option {
+	opts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(endpoint)}
+	if s.tlsConfig != nil {
+		opts = append(opts, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(s.tlsConfig)))
+	} else {
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	}
+	if len(s.headers) > 0 {
+		opts = append(opts, otlpmetricgrpc.WithHeaders(s.headers))
+	}
+	if s.gzip {
+		opts = append(opts, otlpmetricgrpc.WithCompressor("gzip"))
+	}
+	return opts
+}
+
+// logHTTPOptions returns the OTLP/HTTP log exporter options for endpoint
+func (s exporterSettings) logHTTPOptions(endpoint string) []otlploghttp.Option {
+	opts := []otlploghttp.Option{otlploghttp.WithEndpoint(endpoint)}
+	if s.tlsConfig != nil {
+		opts = append(opts, otlploghttp.WithTLSClientConfig(s.tlsConfig))
+	} else {
+		opts = append(opts, otlploghttp.WithInsecure())
+	}
+	if len(s.headers) > 0 {
+		opts = append(opts, otlploghttp.WithHeaders(s.headers))
+	}
+	if s.gzip {
+		opts = append(opts, otlploghttp.WithCompression(otlploghttp.GzipCompression))
+	}
+	return opts
+}
+
+// logGRPCOptions returns the OTLP/gRPC log exporter options for endpoint
+func (s exporterSettings) logGRPCOptions(endpoint string) []otlploggrpc.Option {
+	opts := []otlploggrpc.Option{otlploggrpc.WithEndpoint(endpoint)}
+	if s.tlsConfig != nil {
+		opts = append(opts, otlploggrpc.WithTLSCredentials(credentials.NewTLS(s.tlsConfig)))
+	} else {
+		opts = append(opts, otlploggrpc.WithInsecure())
+	}
+	if len(s.headers) > 0 {
+		opts = append(opts, otlploggrpc.WithHeaders(s.headers))
+	}
+	if s.gzip {
+		opts = append(opts, otlploggrpc.WithCompressor("gzip"))
+	}
+	return opts
+}
@@ -0,0 +1,280 @@
+package observability
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+)
+
+// recordingTraceService is an OTLP gRPC trace collector that captures request metadata
+type recordingTraceService struct {
+	coltracepb.UnimplementedTraceServiceServer
+	received chan metadata.MD
+}
+
+func (s *recordingTraceService) Export(ctx context.Context, _ *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	select {
+	case s.received <- md:
+	default:
+	}
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+// compressionRecorder is a gRPC stats handler capturing the compression of incoming requests
+type compressionRecorder struct {
+	compression chan string
+}
+
+func (r *compressionRecorder) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (r *compressionRecorder) HandleRPC(_ context.Context, s stats.RPCStats) {
+	if header, ok := s.(*stats.InHeader); ok {
+		select {
+		case r.compression <- header.Compression:
+		default:
+		}
+	}
+}
+
+func (r *compressionRecorder) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (r *compressionRecorder) HandleConn(context.Context, stats.ConnStats) {}
+
+// writeTestCertificate writes a self-signed certificate and key valid for 127.0.0.1 and
+// returns the certificate and key file paths
+func writeTestCertificate(t *testing.T, dir, name string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		DNSNames:              []string{name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+
+	certFile := filepath.Join(dir, name+".pem")
+	keyFile := filepath.Join(dir, name+"-key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("failed to write certificate: %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+	return certFile, keyFile
+}
+
+func TestExporterTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	caFile, _ := writeTestCertificate(t, dir, "collector.internal")
+	certFile, keyFile := writeTestCertificate(t, dir, "client")
+
+	t.Run("disabled", func(t *testing.T) {
+		tlsConfig, err := exporterTLSConfig(BaseConfig{OtelExporterCAFile: caFile})
+		if err != nil || tlsConfig != nil {
+			t.Errorf("expected no TLS config when TLS is disabled, got %v (%v)", tlsConfig, err)
+		}
+	})
+
+	t.Run("mTLS", func(t *testing.T) {
+		tlsConfig, err := exporterTLSConfig(BaseConfig{
+			OtelExporterTLS:        true,
+			OtelExporterCAFile:     caFile,
+			OtelExporterCertFile:   certFile,
+			OtelExporterKeyFile:    keyFile,
+			OtelExporterServerName: "collector.internal",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tlsConfig.RootCAs == nil {
+			t.Error("expected custom root CAs")
+		}
+		if len(tlsConfig.Certificates) != 1 {
+			t.Errorf("expected a client certificate, got %d", len(tlsConfig.Certificates))
+		}
+		if tlsConfig.ServerName != "collector.internal" {
+			t.Errorf("expected server name override, got %q", tlsConfig.ServerName)
+		}
+	})
+
+	t.Run("invalid CA bundle", func(t *testing.T) {
+		_, err := exporterTLSConfig(BaseConfig{OtelExporterTLS: true, OtelExporterCAFile: keyFile})
+		if err == nil || !strings.Contains(err.Error(), "no PEM certificates") {
+			t.Errorf("expected CA parse error, got %v", err)
+		}
+	})
+
+	t.Run("mismatched key pair", func(t *testing.T) {
+		_, err := exporterTLSConfig(BaseConfig{
+			OtelExporterTLS:      true,
+			OtelExporterCertFile: certFile,
+			OtelExporterKeyFile:  caFile,
+		})
+		if err == nil {
+			t.Error("expected client certificate load error")
+		}
+	})
+}
+
+func TestExporterSettingsTraceHTTP(t *testing.T) {
+	dir := t.TempDir()
+	serverCert, serverKey := writeTestCertificate(t, dir, "collector.internal")
+	clientCert, clientKey := writeTestCertificate(t, dir, "client")
+
+	received := make(chan *http.Request, 1)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case received <- r:
+		default:
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	cert, err := tls.LoadX509KeyPair(serverCert, serverKey)
+	if err != nil {
+		t.Fatalf("failed to load server certificate: %v", err)
+	}
+	clientCA, err := os.ReadFile(clientCert)
+	if err != nil {
+		t.Fatalf("failed to read client certificate: %v", err)
+	}
+	clientPool := x509.NewCertPool()
+	clientPool.AppendCertsFromPEM(clientCA)
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientPool,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	settings, err := newExporterSettings(BaseConfig{
+		OtelExporterTLS:        true,
+		OtelExporterCAFile:     serverCert,
+		OtelExporterCertFile:   clientCert,
+		OtelExporterKeyFile:    clientKey,
+		OtelExporterServerName: "collector.internal",
+		OtelExporterHeaders:    "authorization=Bearer%20token",
+	})
+	if err != nil {
+		t.Fatalf("newExporterSettings failed: %v", err)
+	}
+
+	ctx := context.Background()
+	endpoint := strings.TrimPrefix(server.URL, "https://")
+	exp, err := otlptracehttp.New(ctx, settings.traceHTTPOptions(endpoint)...)
+	if err != nil {
+		t.Fatalf("failed to create exporter: %v", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	_, span := tp.Tracer("test").Start(ctx, "op")
+	span.End()
+	_ = tp.Shutdown(ctx)
+
+	select {
+	case r := <-received:
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("expected authorization header, got %q", got)
+		}
+		if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+			t.Error("expected the exporter to present a client certificate")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the exporter to reach the TLS collector")
+	}
+}
+
+func TestExporterSettingsTraceGRPC(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	service := &recordingTraceService{received: make(chan metadata.MD, 1)}
+	recorder := &compressionRecorder{compression: make(chan string, 1)}
+	server := grpc.NewServer(grpc.StatsHandler(recorder))
+	coltracepb.RegisterTraceServiceServer(server, service)
+	go func() { _ = server.Serve(ln) }()
+	defer server.Stop()
+
+	settings, err := newExporterSettings(BaseConfig{
+		OtelExporterHeaders:     "x-tenant=acme",
+		OtelExporterCompression: "gzip",
+	})
+	if err != nil {
+		t.Fatalf("newExporterSettings failed: %v", err)
+	}
+
+	ctx := context.Background()
+	exp, err := otlptracegrpc.New(ctx, settings.traceGRPCOptions(ln.Addr().String())...)
+	if err != nil {
+		t.Fatalf("failed to create exporter: %v", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	_, span := tp.Tracer("test").Start(ctx, "op")
+	span.End()
+	_ = tp.Shutdown(ctx)
+
+	select {
+	case md := <-service.received:
+		if got := md.Get("x-tenant"); len(got) != 1 || got[0] != "acme" {
+			t.Errorf("expected x-tenant metadata, got %v", got)
+		}
+		if got := <-recorder.compression; got != "gzip" {
+			t.Errorf("expected gzip compression, got %q", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the exporter to reach the gRPC collector")
+	}
+}
+
+func TestNewExporterSettingsInvalidHeaders(t *testing.T) {
+	if _, err := newExporterSettings(BaseConfig{OtelExporterHeaders: "=value"}); err == nil {
+		t.Error("expected an error for a header without a key")
+	}
+}
@@ -0,0 +1,256 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zapcore"
+)
+
+// otelLogScope is the instrumentation scope name used for bridged zap logs
+const otelLogScope = "github.com/ecoma-io/go-observability"
+
+// newLoggerProvider creates an OTel LoggerProvider exporting over OTLP (HTTP or gRPC)
+func newLoggerProvider(ctx context.Context, cfg BaseConfig, exporter exporterSettings, res *resource.Resource) (*sdklog.LoggerProvider, error) {
+	var (
+		exp sdklog.Exporter
+		err error
+	)
+
+	protocol := strings.ToLower(strings.TrimSpace(cfg.LogsProtocol))
+	switch protocol {
+	case "grpc":
+		exp, err = otlploggrpc.New(ctx, exporter.logGRPCOptions(cfg.LogsExportEndpoint())...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP gRPC log exporter: %w", err)
+		}
+	default:
+		// Default to HTTP if protocol not specified
+		exp, err = otlploghttp.New(ctx, exporter.logHTTPOptions(cfg.LogsExportEndpoint())...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP HTTP log exporter: %w", err)
+		}
+	}
+
+	return sdklog.NewLoggerProvider(
+		sdklog.WithResource(res),
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(exp)),
+	), nil
+}
+
+// otelCore is a zapcore.Core that forwards log entries to the global OTel LoggerProvider.
+// Entries logged with a context (Logger.WithContext and the *Ctx methods) are emitted with it,
+// so the SDK correlates them with the span in that context.
+type otelCore struct {
+	zapcore.LevelEnabler
+	logger otellog.Logger
+	fields []zapcore.Field
+	// ctx is the context carried by a contextField passed to With
+	ctx context.Context
+}
+
+// newOtelCore creates a zap core bridging to the global OTel LoggerProvider.
+// The provider is resolved lazily, so the core may be created before InitOtel runs.
+func newOtelCore(level zapcore.LevelEnabler) zapcore.Core {
+	return &otelCore{
+		LevelEnabler: level,
+		logger:       global.Logger(otelLogScope),
+	}
+}
+
+// With returns a copy of the core with additional fields
+func (c *otelCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.fields = append([]zapcore.Field(nil), c.fields...)
+	for _, f := range fields {
+		if ctx, ok := fieldContext(f); ok {
+			clone.ctx = ctx
+			continue
+		}
+		clone.fields = append(clone.fields, f)
+	}
+	return &clone
+}
+
+// fieldContext returns the context carried by a contextField
+func fieldContext(f zapcore.Field) (context.Context, bool) {
+	if f.Type != zapcore.SkipType {
+		return nil, false
+	}
+	ctx, ok := f.Interface.(context.Context)
+	return ctx, ok && ctx != nil
+}
+
+// Check adds the core to the checked entry if the level is enabled
+func (c *otelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write converts the entry into an OTel log record and emits it
+func (c *otelCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ctx := c.ctx
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range c.fields {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		if fieldCtx, ok := fieldContext(f); ok {
+			ctx = fieldCtx
+			continue
+		}
+		f.AddTo(enc)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// The SDK reads the trace ID, span ID and flags from ctx; drop their console fields
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		delete(enc.Fields, "trace_id")
+		delete(enc.Fields, "span_id")
+		delete(enc.Fields, "trace_flags")
+	}
+
+	var record otellog.Record
+	record.SetTimestamp(ent.Time)
+	record.SetObservedTimestamp(time.Now())
+	record.SetSeverity(otelSeverity(ent.Level))
+	record.SetSeverityText(ent.Level.CapitalString())
+	record.SetBody(otellog.StringValue(ent.Message))
+
+	if ent.Caller.Defined {
+		record.AddAttributes(
+			otellog.String("code.filepath", ent.Caller.File),
+			otellog.Int("code.lineno", ent.Caller.Line),
+		)
+	}
+	if ent.Stack != "" {
+		record.AddAttributes(otellog.String("exception.stacktrace", ent.Stack))
+	}
+
+	// Sort keys so attribute order is deterministic
+	keys := make([]string, 0, len(enc.Fields))
+	for k := range enc.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		record.AddAttributes(otellog.KeyValue{Key: k, Value: otelLogValue(enc.Fields[k])})
+	}
+
+	c.logger.Emit(ctx, record)
+	return nil
+}
+
+// Sync is a no-op; buffered records are flushed by the LoggerProvider
+func (c *otelCore) Sync() error {
+	return nil
+}
+
+// otelSeverity maps zap levels to OTel log severities
+func otelSeverity(level zapcore.Level) otellog.Severity {
+	switch level {
+	case zapcore.DebugLevel:
+		return otellog.SeverityDebug
+	case zapcore.InfoLevel:
+		return otellog.SeverityInfo
+	case zapcore.WarnLevel:
+		return otellog.SeverityWarn
+	case zapcore.ErrorLevel:
+		return otellog.SeverityError
+	case zapcore.DPanicLevel:
+		return otellog.SeverityFatal1
+	case zapcore.PanicLevel:
+		return otellog.SeverityFatal2
+	case zapcore.FatalLevel:
+		return otellog.SeverityFatal3
+	default:
+		return otellog.SeverityUndefined
+	}
+}
+
+// otelLogValue converts a value produced by zapcore.MapObjectEncoder into an OTel log value
+func otelLogValue(v interface{}) otellog.Value {
+	switch val := v.(type) {
+	case nil:
+		return otellog.Value{}
+	case string:
+		return otellog.StringValue(val)
+	case bool:
+		return otellog.BoolValue(val)
+	case int:
+		return otellog.IntValue(val)
+	case int8:
+		return otellog.Int64Value(int64(val))
+	case int16:
+		return otellog.Int64Value(int64(val))
+	case int32:
+		return otellog.Int64Value(int64(val))
+	case int64:
+		return otellog.Int64Value(val)
+	case uint:
+		return otelLogUint(uint64(val))
+	case uint8:
+		return otellog.Int64Value(int64(val))
+	case uint16:
+		return otellog.Int64Value(int64(val))
+	case uint32:
+		return otellog.Int64Value(int64(val))
+	case uint64:
+		return otelLogUint(val)
+	case uintptr:
+		return otelLogUint(uint64(val))
+	case float32:
+		return otellog.Float64Value(float64(val))
+	case float64:
+		return otellog.Float64Value(val)
+	case complex64, complex128:
+		return otellog.StringValue(fmt.Sprintf("%v", val))
+	case []byte:
+		return otellog.BytesValue(val)
+	case time.Time:
+		return otellog.StringValue(val.Format(time.RFC3339Nano))
+	case time.Duration:
+		return otellog.Int64Value(val.Milliseconds())
+	case []interface{}:
+		values := make([]otellog.Value, 0, len(val))
+		for _, item := range val {
+			values = append(values, otelLogValue(item))
+		}
+		return otellog.SliceValue(values...)
+	case map[string]interface{}:
+		kvs := make([]otellog.KeyValue, 0, len(val))
+		for k, item := range val {
+			kvs = append(kvs, otellog.KeyValue{Key: k, Value: otelLogValue(item)})
+		}
+		return otellog.MapValue(kvs...)
+	case error:
+		return otellog.StringValue(val.Error())
+	case fmt.Stringer:
+		return otellog.StringValue(val.String())
+	default:
+		return otellog.StringValue(fmt.Sprintf("%v", val))
+	}
+}
+
+// otelLogUint converts an unsigned integer, falling back to a string when it overflows int64
+func otelLogUint(v uint64) otellog.Value {
+	if v > math.MaxInt64 {
+		return otellog.StringValue(fmt.Sprintf("%d", v))
+	}
+	return otellog.Int64Value(int64(v))
+}
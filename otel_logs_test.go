@@ -0,0 +1,200 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// recordingLogProcessor is an sdklog.Processor that keeps emitted records in memory
+type recordingLogProcessor struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (p *recordingLogProcessor) OnEmit(ctx context.Context, r *sdklog.Record) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.records = append(p.records, r.Clone())
+	return nil
+}
+
+func (p *recordingLogProcessor) Enabled(ctx context.Context, param sdklog.EnabledParameters) bool {
+	return true
+}
+
+func (p *recordingLogProcessor) Shutdown(ctx context.Context) error   { return nil }
+func (p *recordingLogProcessor) ForceFlush(ctx context.Context) error { return nil }
+
+func (p *recordingLogProcessor) Records() []sdklog.Record {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]sdklog.Record(nil), p.records...)
+}
+
+// setupTestLogs installs an in-memory LoggerProvider as global
+func setupTestLogs(t *testing.T) *recordingLogProcessor {
+	t.Helper()
+
+	processor := &recordingLogProcessor{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(processor))
+	global.SetLoggerProvider(lp)
+	t.Cleanup(func() { _ = lp.Shutdown(context.Background()) })
+
+	return processor
+}
+
+func recordAttribute(r sdklog.Record, key string) (otellog.Value, bool) {
+	var (
+		value otellog.Value
+		found bool
+	)
+	r.WalkAttributes(func(kv otellog.KeyValue) bool {
+		if kv.Key == key {
+			value, found = kv.Value, true
+			return false
+		}
+		return true
+	})
+	return value, found
+}
+
+func TestOtelCore(t *testing.T) {
+	processor := setupTestLogs(t)
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	l := zap.New(newOtelCore(zapcore.InfoLevel)).With(zap.String("service", "test-otel-core"))
+	l.Debug("filtered out")
+	l.Warn("cache miss",
+		zap.String("key", "user:1"),
+		zap.Int("attempt", 3),
+		zap.Bool("cold", true),
+		zap.Error(errors.New("timeout")),
+		zap.Duration("elapsed", 1500*time.Millisecond),
+		zap.String("trace_id", traceID.String()),
+		zap.String("span_id", spanID.String()),
+		contextField(ctx),
+	)
+
+	records := processor.Records()
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record (debug filtered), got %d", len(records))
+	}
+	r := records[0]
+
+	if r.Body().AsString() != "cache miss" {
+		t.Errorf("expected body 'cache miss', got %q", r.Body().AsString())
+	}
+	if r.Severity() != otellog.SeverityWarn || r.SeverityText() != "WARN" {
+		t.Errorf("expected WARN severity, got %v (%s)", r.Severity(), r.SeverityText())
+	}
+	if r.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected trace id to be correlated, got %s", r.TraceID())
+	}
+	if r.SpanID().String() != "00f067aa0ba902b7" {
+		t.Errorf("expected span id to be correlated, got %s", r.SpanID())
+	}
+	if !r.TraceFlags().IsSampled() {
+		t.Error("expected the trace flags to be filled in from the context")
+	}
+	if _, ok := recordAttribute(r, "trace_id"); ok {
+		t.Error("expected trace_id to be carried natively, not as an attribute")
+	}
+	if _, ok := recordAttribute(r, "context"); ok {
+		t.Error("expected the context field not to become an attribute")
+	}
+
+	if v, _ := recordAttribute(r, "service"); v.AsString() != "test-otel-core" {
+		t.Errorf("expected service attribute from With(), got %v", v)
+	}
+	if v, _ := recordAttribute(r, "key"); v.AsString() != "user:1" {
+		t.Errorf("expected key=user:1, got %v", v)
+	}
+	if v, _ := recordAttribute(r, "attempt"); v.AsInt64() != 3 {
+		t.Errorf("expected attempt=3, got %v", v)
+	}
+	if v, _ := recordAttribute(r, "cold"); !v.AsBool() {
+		t.Errorf("expected cold=true, got %v", v)
+	}
+	if v, _ := recordAttribute(r, "error"); v.AsString() != "timeout" {
+		t.Errorf("expected error=timeout, got %v", v)
+	}
+	if v, _ := recordAttribute(r, "elapsed"); v.AsInt64() != 1500 {
+		t.Errorf("expected elapsed=1500 (ms), got %v", v)
+	}
+}
+
+func TestOtelCoreWithoutTraceContext(t *testing.T) {
+	processor := setupTestLogs(t)
+
+	// Correlation comes from the context only, never from string fields
+	l := zap.New(newOtelCore(zapcore.DebugLevel))
+	l.Info("no trace", zap.String("trace_id", "4bf92f3577b34da6a3ce929d0e0e4736"))
+
+	records := processor.Records()
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	if records[0].TraceID().IsValid() {
+		t.Error("expected no trace correlation without a context")
+	}
+	if v, _ := recordAttribute(records[0], "trace_id"); v.AsString() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected trace_id to be kept as attribute, got %v", v)
+	}
+}
+
+func TestNewLoggerTeesToOTLP(t *testing.T) {
+	processor := setupTestLogs(t)
+
+	l := NewLogger(&BaseConfig{ServiceName: "test-tee", LogLevel: "info", LogsMode: "both"})
+	l.Info("teed message", "key", "val")
+
+	records := processor.Records()
+	if len(records) != 1 {
+		t.Fatalf("expected 1 OTLP record, got %d", len(records))
+	}
+	if v, _ := recordAttribute(records[0], "service"); v.AsString() != "test-tee" {
+		t.Errorf("expected service attribute, got %v", v)
+	}
+
+	// Context-aware entries are emitted with their context
+	recorder := setupTestTracing(t)
+	ctx, span := otel.Tracer("test").Start(context.Background(), "op")
+	l.WithContext(ctx).Info("in span")
+	l.InfoCtx(ctx, "in span ctx")
+	span.End()
+	if len(recorder.Ended()) != 1 {
+		t.Fatal("expected the test span to be recorded")
+	}
+	for _, r := range processor.Records()[1:] {
+		if r.TraceID() != span.SpanContext().TraceID() || r.SpanID() != span.SpanContext().SpanID() || r.TraceFlags() != span.SpanContext().TraceFlags() {
+			t.Errorf("%s: expected the record to carry the span context, got %s/%s/%s", r.Body().AsString(), r.TraceID(), r.SpanID(), r.TraceFlags())
+		}
+	}
+	if got := len(processor.Records()); got != 3 {
+		t.Fatalf("expected 3 OTLP records, got %d", got)
+	}
+
+	// stdout-only loggers do not forward to the LoggerProvider
+	NewLogger(&BaseConfig{ServiceName: "test-tee", LogLevel: "info", LogsMode: "stdout"}).Info("stdout only")
+	if got := len(processor.Records()); got != 3 {
+		t.Errorf("expected stdout-only logger not to emit OTLP records, got %d", got)
+	}
+}
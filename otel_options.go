@@ -0,0 +1,148 @@
+package observability
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Option customizes InitOtelWithOptions
+type Option func(*otelOptions)
+
+// otelOptions holds the components supplied through Option values; nil fields fall back to
+// the ones built from BaseConfig
+type otelOptions struct {
+	spanExporter   sdktrace.SpanExporter
+	spanProcessors []sdktrace.SpanProcessor
+	sampler        sdktrace.Sampler
+	metricReaders  []sdkmetric.Reader
+	views          []sdkmetric.View
+	propagator     propagation.TextMapPropagator
+	resource       *resource.Resource
+	logger         *Logger
+	health         *HealthRegistry
+	promCollectors []prometheus.Collector
+	skipGlobals    bool
+}
+
+func newOtelOptions(options []Option) *otelOptions {
+	o := &otelOptions{}
+	for _, opt := range options {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	return o
+}
+
+// WithSpanExporter replaces the OTLP trace exporter. Spans still go through the batch
+// processor (and the tail sampling processor when enabled).
+func WithSpanExporter(exporter sdktrace.SpanExporter) Option {
+	return func(o *otelOptions) {
+		o.spanExporter = exporter
+	}
+}
+
+// WithSpanProcessor registers an additional span processor after the batch processor.
+// It sees every sampled span, regardless of tail sampling.
+func WithSpanProcessor(processor sdktrace.SpanProcessor) Option {
+	return func(o *otelOptions) {
+		o.spanProcessors = append(o.spanProcessors, processor)
+	}
+}
+
+// WithSampler replaces the sampler built from OTEL_TRACES_SAMPLER and its related settings
+func WithSampler(sampler sdktrace.Sampler) Option {
+	return func(o *otelOptions) {
+		o.sampler = sampler
+	}
+}
+
+// WithMetricReader registers an additional metric reader next to those selected by MetricsMode.
+// When MetricsMode selects none, the fallback Prometheus server is not started.
+func WithMetricReader(reader sdkmetric.Reader) Option {
+	return func(o *otelOptions) {
+		o.metricReaders = append(o.metricReaders, reader)
+	}
+}
+
+// WithView adds views to the MeterProvider
+func WithView(views ...sdkmetric.View) Option {
+	return func(o *otelOptions) {
+		o.views = append(o.views, views...)
+	}
+}
+
+// WithPropagator replaces the propagator built from OTEL_PROPAGATORS
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(o *otelOptions) {
+		o.propagator = propagator
+	}
+}
+
+// WithResource replaces the detected resource shared by traces, metrics and logs
+func WithResource(res *resource.Resource) Option {
+	return func(o *otelOptions) {
+		o.resource = res
+	}
+}
+
+// WithLogger sets the logger used for internal errors: OpenTelemetry SDK errors, metrics
+// server failures and level changes through /loglevel. Without it, errors are printed to
+// stdout and /loglevel controls DefaultLogger().
+func WithLogger(logger *Logger) Option {
+	return func(o *otelOptions) {
+		o.logger = logger
+	}
+}
+
+// WithHealthRegistry serves the checks of registry on /livez and /readyz instead of a new,
+// empty registry. Checks can be registered before or after InitOtelWithOptions.
+func WithHealthRegistry(registry *HealthRegistry) Option {
+	return func(o *otelOptions) {
+		o.health = registry
+	}
+}
+
+// WithPrometheusCollectors registers collectors on the private Prometheus registry served by
+// the pull server, e.g. collectors.NewGoCollector() or metrics of a library built on
+// client_golang. InitOtelWithOptions fails if one of them cannot be registered.
+func WithPrometheusCollectors(collectors ...prometheus.Collector) Option {
+	return func(o *otelOptions) {
+		o.promCollectors = append(o.promCollectors, collectors...)
+	}
+}
+
+// WithoutGlobals keeps the providers, propagator and error handler out of the otel globals, so
+// several isolated instances can coexist in one process (e.g. in tests). Use the Telemetry
+// handle to reach them; helpers such as GetTracer, GetMeter and the middlewares keep using the
+// globals.
+func WithoutGlobals() Option {
+	return func(o *otelOptions) {
+		o.skipGlobals = true
+	}
+}
+
+// errorHandler returns an otel.ErrorHandler logging to the configured logger, or nil without one
+func (o *otelOptions) errorHandler() otel.ErrorHandler {
+	if o.logger == nil {
+		return nil
+	}
+	return otel.ErrorHandlerFunc(func(err error) {
+		o.logger.Error("OpenTelemetry error", "error", err)
+	})
+}
+
+// logError reports an internal error to the configured logger, or stdout without one
+func (o *otelOptions) logError(msg string, err error) {
+	if o.logger != nil {
+		o.logger.Error(msg, "error", err)
+		return
+	}
+	fmt.Printf("%s: %v\n", msg, err)
+}
@@ -0,0 +1,114 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInitOtelWithOptions(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	recorder := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	res := resource.NewSchemaless(attribute.String("service.name", "custom-resource"))
+	propagator := propagation.NewCompositeTextMapPropagator(propagation.Baggage{})
+	logger, logs := newObservedLogger()
+
+	// No MetricsMode: the custom reader is the only one and no metrics server is started
+	cfg := BaseConfig{ServiceName: "options-service", OtelTracingSampleRate: 0}
+
+	tel, err := InitOtelWithOptions(cfg,
+		WithSpanExporter(exporter),
+		WithSpanProcessor(recorder),
+		WithSampler(sdktrace.AlwaysSample()),
+		WithMetricReader(reader),
+		WithView(sdkmetric.NewView(
+			sdkmetric.Instrument{Name: "options.requests"},
+			sdkmetric.Stream{Name: "options.requests.renamed"},
+		)),
+		WithPropagator(propagator),
+		WithResource(res),
+		WithLogger(logger),
+	)
+	if err != nil {
+		t.Fatalf("InitOtelWithOptions failed: %v", err)
+	}
+
+	_, span := GetTracer("options-test").Start(context.Background(), "operation")
+	span.End()
+
+	counter, err := GetMeter("options-test").Int64Counter("options.requests")
+	if err != nil {
+		t.Fatalf("failed to create counter: %v", err)
+	}
+	counter.Add(context.Background(), 1)
+
+	t.Run("Span Processor And Sampler", func(t *testing.T) {
+		ended := recorder.Ended()
+		if len(ended) != 1 {
+			t.Fatalf("expected 1 recorded span despite a zero sample rate, got %d", len(ended))
+		}
+		if got := ended[0].Resource(); !got.Equal(res) {
+			t.Errorf("expected the custom resource, got %v", got.Attributes())
+		}
+	})
+
+	t.Run("Span Exporter", func(t *testing.T) {
+		if err := tel.ForceFlush(context.Background()); err != nil {
+			t.Fatalf("ForceFlush failed: %v", err)
+		}
+		if got := len(exporter.GetSpans()); got != 1 {
+			t.Errorf("expected 1 exported span, got %d", got)
+		}
+	})
+
+	t.Run("Metric Reader And View", func(t *testing.T) {
+		if _, ok := findMetric(t, reader, "options.requests.renamed"); !ok {
+			t.Error("expected the view to rename options.requests")
+		}
+	})
+
+	t.Run("Propagator", func(t *testing.T) {
+		fields := otel.GetTextMapPropagator().Fields()
+		if len(fields) != 1 || fields[0] != "baggage" {
+			t.Errorf("expected only the baggage propagator to be installed, got fields %v", fields)
+		}
+	})
+
+	t.Run("Logger", func(t *testing.T) {
+		otel.Handle(errors.New("export failed"))
+		if got := logs.FilterMessage("OpenTelemetry error").Len(); got != 1 {
+			t.Errorf("expected 1 logged OpenTelemetry error, got %d", got)
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := tel.Shutdown(ctx); err != nil {
+		t.Fatalf("shutdown failed: %v", err)
+	}
+}
+
+func TestInitOtelWithOptions_NilOption(t *testing.T) {
+	cfg := BaseConfig{ServiceName: "nil-option-service"}
+
+	tel, err := InitOtelWithOptions(cfg, nil,
+		WithSpanExporter(tracetest.NewInMemoryExporter()),
+		WithMetricReader(sdkmetric.NewManualReader()),
+	)
+	if err != nil {
+		t.Fatalf("InitOtelWithOptions failed: %v", err)
+	}
+	if err := tel.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown failed: %v", err)
+	}
+}
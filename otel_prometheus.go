@@ -0,0 +1,34 @@
+package observability
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// newPrometheusRegistry creates the private registry behind the Prometheus exporter and the
+// pull server, holding the collectors supplied through WithPrometheusCollectors
+func newPrometheusRegistry(collectors []prometheus.Collector) (*prometheus.Registry, error) {
+	registry := prometheus.NewRegistry()
+	for _, collector := range collectors {
+		if collector == nil {
+			continue
+		}
+		if err := registry.Register(collector); err != nil {
+			return nil, fmt.Errorf("failed to register prometheus collector: %w", err)
+		}
+	}
+	return registry, nil
+}
+
+// newPrometheusHandler serves gatherer in the Prometheus text format, or in OpenMetrics (with
+// exemplars) when the scraper asks for it, and registers the handler's own metrics on registry.
+// Responses are gzip-compressed when the scraper accepts it.
+func newPrometheusHandler(registry *prometheus.Registry, gatherer prometheus.Gatherer) http.Handler {
+	return promhttp.InstrumentMetricHandler(registry,
+		promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{
+			EnableOpenMetrics: true,
+		}))
+}
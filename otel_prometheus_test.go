@@ -0,0 +1,181 @@
+package observability
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// scrapeMetrics fetches /metrics from tel with the given request headers
+func scrapeMetrics(t *testing.T, tel *Telemetry, header http.Header) (*http.Response, string) {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, "http://"+tel.MetricsAddr()+"/metrics", nil)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to scrape metrics: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var reader io.Reader = resp.Body
+	if resp.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			t.Fatalf("failed to read gzip body: %v", err)
+		}
+		reader = gz
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("failed to read metrics: %v", err)
+	}
+	return resp, string(body)
+}
+
+func TestNewPrometheusRegistry(t *testing.T) {
+	newCounter := func() prometheus.Counter {
+		return prometheus.NewCounter(prometheus.CounterOpts{Name: "registry_test_total", Help: "Test counter"})
+	}
+
+	registry, err := newPrometheusRegistry([]prometheus.Collector{newCounter(), nil})
+	if err != nil {
+		t.Fatalf("newPrometheusRegistry failed: %v", err)
+	}
+	families, err := registry.Gather()
+	if err != nil || len(families) != 1 || families[0].GetName() != "registry_test_total" {
+		t.Errorf("expected only the supplied collector, got %v (err=%v)", families, err)
+	}
+
+	if _, err := newPrometheusRegistry([]prometheus.Collector{newCounter(), newCounter()}); err == nil {
+		t.Error("expected an error for a duplicate collector")
+	}
+}
+
+func TestInitOtel_PrometheusRegistry(t *testing.T) {
+	// Registered by an unrelated library on the default registry
+	leaked := prometheus.NewCounter(prometheus.CounterOpts{Name: "default_registry_leak_total", Help: "Test counter"})
+	prometheus.MustRegister(leaked)
+	defer prometheus.Unregister(leaked)
+
+	var instances []*Telemetry
+	for _, name := range []string{"first-pull-service", "second-pull-service"} {
+		extra := prometheus.NewCounter(prometheus.CounterOpts{
+			Name:        "extra_collector_total",
+			Help:        "Test counter",
+			ConstLabels: prometheus.Labels{"owner": name},
+		})
+		cfg := BaseConfig{ServiceName: name, MetricsMode: "pull", MetricsPath: "/metrics"}
+		tel, err := InitOtelWithOptions(cfg,
+			WithoutGlobals(),
+			WithSpanExporter(tracetest.NewInMemoryExporter()),
+			WithPrometheusCollectors(extra),
+		)
+		if err != nil {
+			t.Fatalf("InitOtelWithOptions failed: %v", err)
+		}
+		t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+		instances = append(instances, tel)
+	}
+
+	if instances[0].PrometheusRegistry() == nil || instances[0].PrometheusRegistry() == instances[1].PrometheusRegistry() {
+		t.Fatal("expected each instance to own its Prometheus registry")
+	}
+	counter, _ := instances[0].MeterProvider().Meter("registry-test").Int64Counter("only_first_requests")
+	counter.Add(context.Background(), 1)
+
+	_, first := scrapeMetrics(t, instances[0], nil)
+	_, second := scrapeMetrics(t, instances[1], nil)
+
+	if !strings.Contains(first, "only_first_requests_total") {
+		t.Errorf("expected the first instance to serve its counter:\n%s", first)
+	}
+	if strings.Contains(second, "only_first_requests_total") {
+		t.Error("expected the second instance not to serve the first instance's counter")
+	}
+	if !strings.Contains(first, `extra_collector_total{owner="first-pull-service"}`) ||
+		strings.Contains(first, `owner="second-pull-service"`) {
+		t.Errorf("expected only the first instance's extra collector:\n%s", first)
+	}
+	if strings.Contains(first, "default_registry_leak_total") {
+		t.Error("expected the default registry to stay off the endpoint")
+	}
+}
+
+func TestInitOtel_PrometheusCollectorConflict(t *testing.T) {
+	newCounter := func() prometheus.Counter {
+		return prometheus.NewCounter(prometheus.CounterOpts{Name: "conflicting_total", Help: "Test counter"})
+	}
+	cfg := BaseConfig{ServiceName: "conflict-service", MetricsMode: "pull", MetricsPath: "/metrics"}
+	tel, err := InitOtelWithOptions(cfg,
+		WithoutGlobals(),
+		WithSpanExporter(tracetest.NewInMemoryExporter()),
+		WithPrometheusCollectors(newCounter(), newCounter()),
+	)
+	if err == nil {
+		_ = tel.Shutdown(context.Background())
+		t.Fatal("expected an error for conflicting collectors")
+	}
+}
+
+func TestInitOtel_PrometheusScrapeFormats(t *testing.T) {
+	cfg := BaseConfig{
+		ServiceName:           "formats-service",
+		MetricsMode:           "pull",
+		MetricsPath:           "/metrics",
+		OtelTracingSampleRate: 1,
+	}
+	tel, err := InitOtelWithOptions(cfg, WithoutGlobals(), WithSpanExporter(tracetest.NewInMemoryExporter()))
+	if err != nil {
+		t.Fatalf("InitOtelWithOptions failed: %v", err)
+	}
+	defer func() { _ = tel.Shutdown(context.Background()) }()
+
+	// Measurements recorded in a sampled span carry an exemplar
+	ctx, span := tel.TracerProvider().Tracer("formats-test").Start(context.Background(), "request")
+	latency, _ := tel.MeterProvider().Meter("formats-test").Float64Histogram("formats_latency", metric.WithUnit("s"))
+	latency.Record(ctx, 0.2)
+	span.End()
+	traceID := span.SpanContext().TraceID().String()
+
+	t.Run("Prometheus Text", func(t *testing.T) {
+		resp, body := scrapeMetrics(t, tel, nil)
+		if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+			t.Errorf("expected the text format, got %q", ct)
+		}
+		if strings.Contains(body, traceID) {
+			t.Error("expected no exemplars in the text format")
+		}
+	})
+
+	t.Run("OpenMetrics", func(t *testing.T) {
+		resp, body := scrapeMetrics(t, tel, http.Header{"Accept": {"application/openmetrics-text"}})
+		if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/openmetrics-text") {
+			t.Errorf("expected OpenMetrics, got %q", ct)
+		}
+		if !strings.Contains(body, `trace_id="`+traceID+`"`) {
+			t.Errorf("expected an exemplar linking to the trace:\n%s", body)
+		}
+		if !strings.HasSuffix(body, "# EOF\n") {
+			t.Error("expected the OpenMetrics EOF marker")
+		}
+	})
+
+	t.Run("Gzip", func(t *testing.T) {
+		resp, body := scrapeMetrics(t, tel, http.Header{"Accept-Encoding": {"gzip"}})
+		if enc := resp.Header.Get("Content-Encoding"); enc != "gzip" {
+			t.Errorf("expected a gzip response, got %q", enc)
+		}
+		if !strings.Contains(body, "formats_latency_seconds_bucket") {
+			t.Errorf("expected the histogram in the decompressed body:\n%s", body)
+		}
+	})
+}
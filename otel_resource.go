@@ -0,0 +1,104 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// serviceBuildTimeKey carries BuildTime, which has no semantic-convention attribute
+const serviceBuildTimeKey = attribute.Key("service.build_time")
+
+// k8sResourceEnv maps the env vars usually filled from the Kubernetes downward API onto resource attributes
+var k8sResourceEnv = []struct {
+	name string
+	attr func(string) attribute.KeyValue
+}{
+	{"K8S_POD_NAME", semconv.K8SPodName},
+	{"K8S_POD_UID", semconv.K8SPodUID},
+	{"K8S_NAMESPACE_NAME", semconv.K8SNamespaceName},
+	{"K8S_NODE_NAME", semconv.K8SNodeName},
+}
+
+// k8sDetector reads pod, namespace and node from downward-API env vars
+type k8sDetector struct{}
+
+// Detect implements resource.Detector
+func (k8sDetector) Detect(context.Context) (*resource.Resource, error) {
+	var attrs []attribute.KeyValue
+	for _, env := range k8sResourceEnv {
+		if value, ok := lookupEnv(env.name); ok {
+			attrs = append(attrs, env.attr(value))
+		}
+	}
+	if len(attrs) == 0 {
+		return resource.Empty(), nil
+	}
+	return resource.NewSchemaless(attrs...), nil
+}
+
+// newResource builds the resource describing the service, merged over resource.Default().
+// Later sources win: detected host/OS/process/container/Kubernetes attributes, then
+// OTEL_RESOURCE_ATTRIBUTES, then RESOURCE_ATTRIBUTES, then the BaseConfig fields.
+func newResource(ctx context.Context, cfg BaseConfig) (*resource.Resource, error) {
+	custom, err := cfg.CustomResourceAttributes()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithHost(),
+		resource.WithOS(),
+		// Command-line arguments and the process owner are left out, as they may carry secrets
+		resource.WithProcessPID(),
+		resource.WithProcessExecutableName(),
+		resource.WithProcessExecutablePath(),
+		resource.WithProcessRuntimeName(),
+		resource.WithProcessRuntimeVersion(),
+		resource.WithProcessRuntimeDescription(),
+		resource.WithContainerID(),
+		resource.WithDetectors(k8sDetector{}),
+		resource.WithFromEnv(),
+		resource.WithAttributes(custom...),
+		resource.WithAttributes(serviceAttributes(cfg)...),
+	)
+	if err != nil {
+		// A detector failing (e.g. no container ID) still leaves a usable resource
+		if !errors.Is(err, resource.ErrPartialResource) || res == nil {
+			return nil, fmt.Errorf("failed to create resource: %w", err)
+		}
+		otel.Handle(err)
+	}
+
+	merged, err := resource.Merge(resource.Default(), res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge resource: %w", err)
+	}
+	return merged, nil
+}
+
+// serviceAttributes returns the resource attributes taken from BaseConfig fields
+func serviceAttributes(cfg BaseConfig) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		semconv.ServiceName(cfg.ServiceName),
+		semconv.ServiceVersion(cfg.Version),
+	}
+	if cfg.BuildTime != "" && cfg.BuildTime != "unknown" {
+		attrs = append(attrs, serviceBuildTimeKey.String(cfg.BuildTime))
+	}
+	if cfg.ServiceNamespace != "" {
+		attrs = append(attrs, semconv.ServiceNamespace(cfg.ServiceNamespace))
+	}
+	if cfg.ServiceInstanceID != "" {
+		attrs = append(attrs, semconv.ServiceInstanceID(cfg.ServiceInstanceID))
+	}
+	if cfg.DeploymentEnvironment != "" {
+		attrs = append(attrs, semconv.DeploymentEnvironment(cfg.DeploymentEnvironment))
+	}
+	return attrs
+}
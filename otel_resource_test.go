@@ -0,0 +1,132 @@
+package observability
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// resourceValue returns the string value of key in res
+func resourceValue(res *resource.Resource, key attribute.Key) (string, bool) {
+	value, ok := res.Set().Value(key)
+	return value.Emit(), ok
+}
+
+func TestNewResource(t *testing.T) {
+	unsetEnv(t, "K8S_POD_NAME", "K8S_POD_UID", "K8S_NAMESPACE_NAME", "K8S_NODE_NAME")
+	t.Setenv("K8S_POD_NAME", "checkout-7d9f")
+	t.Setenv("K8S_NAMESPACE_NAME", "shop")
+	t.Setenv("K8S_NODE_NAME", "node-1")
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "team=payments,deployment.environment=from-env,service.name=ignored")
+
+	cfg := BaseConfig{
+		ServiceName:           "checkout",
+		Version:               "1.2.3",
+		BuildTime:             "2024-05-01T10:00:00Z",
+		ServiceNamespace:      "shop",
+		ServiceInstanceID:     "checkout-7d9f",
+		DeploymentEnvironment: "production",
+		ResourceAttributes:    "team=checkout,region=eu-west-1",
+	}
+
+	res, err := newResource(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("newResource failed: %v", err)
+	}
+
+	want := map[attribute.Key]string{
+		semconv.ServiceNameKey:           "checkout",
+		semconv.ServiceVersionKey:        "1.2.3",
+		serviceBuildTimeKey:              "2024-05-01T10:00:00Z",
+		semconv.ServiceNamespaceKey:      "shop",
+		semconv.ServiceInstanceIDKey:     "checkout-7d9f",
+		semconv.DeploymentEnvironmentKey: "production",
+		semconv.K8SPodNameKey:            "checkout-7d9f",
+		semconv.K8SNamespaceNameKey:      "shop",
+		semconv.K8SNodeNameKey:           "node-1",
+		// RESOURCE_ATTRIBUTES wins over OTEL_RESOURCE_ATTRIBUTES
+		"team":   "checkout",
+		"region": "eu-west-1",
+	}
+	for key, expected := range want {
+		if got, ok := resourceValue(res, key); !ok || got != expected {
+			t.Errorf("expected %s=%q, got %q (present=%v)", key, expected, got, ok)
+		}
+	}
+
+	// Detected and default attributes
+	for _, key := range []attribute.Key{
+		semconv.HostNameKey,
+		semconv.OSTypeKey,
+		semconv.ProcessPIDKey,
+		semconv.ProcessRuntimeNameKey,
+		semconv.TelemetrySDKLanguageKey,
+	} {
+		if _, ok := resourceValue(res, key); !ok {
+			t.Errorf("expected %s to be detected", key)
+		}
+	}
+
+	// Command-line arguments may carry secrets
+	if _, ok := resourceValue(res, semconv.ProcessCommandArgsKey); ok {
+		t.Error("expected process.command_args to be left out")
+	}
+	if _, ok := resourceValue(res, semconv.K8SPodUIDKey); ok {
+		t.Error("expected no k8s.pod.uid when K8S_POD_UID is unset")
+	}
+}
+
+func TestNewResource_OptionalAttributes(t *testing.T) {
+	unsetEnv(t, "OTEL_RESOURCE_ATTRIBUTES")
+
+	t.Run("Unset Fields Are Omitted", func(t *testing.T) {
+		res, err := newResource(context.Background(), BaseConfig{ServiceName: "svc", BuildTime: "unknown"})
+		if err != nil {
+			t.Fatalf("newResource failed: %v", err)
+		}
+		for _, key := range []attribute.Key{
+			serviceBuildTimeKey,
+			semconv.ServiceNamespaceKey,
+			semconv.ServiceInstanceIDKey,
+			semconv.DeploymentEnvironmentKey,
+		} {
+			// resource.Default() is computed once per process and may carry earlier env attributes
+			if _, inDefault := resourceValue(resource.Default(), key); inDefault {
+				continue
+			}
+			if _, ok := resourceValue(res, key); ok {
+				t.Errorf("expected %s to be omitted", key)
+			}
+		}
+	})
+
+	t.Run("Invalid Resource Attributes", func(t *testing.T) {
+		if _, err := newResource(context.Background(), BaseConfig{ServiceName: "svc", ResourceAttributes: "team"}); err == nil {
+			t.Error("expected an error for an invalid RESOURCE_ATTRIBUTES")
+		}
+	})
+}
+
+func TestK8sDetector(t *testing.T) {
+	unsetEnv(t, "K8S_POD_NAME", "K8S_POD_UID", "K8S_NAMESPACE_NAME", "K8S_NODE_NAME")
+
+	res, err := k8sDetector{}.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if res.Len() != 0 {
+		t.Errorf("expected an empty resource outside Kubernetes, got %v", res.Attributes())
+	}
+
+	t.Setenv("K8S_POD_UID", "0b5c1a4e")
+	res, err = k8sDetector{}.Detect(context.Background())
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if got, ok := resourceValue(res, semconv.K8SPodUIDKey); !ok || got != "0b5c1a4e" {
+		t.Errorf("expected k8s.pod.uid=0b5c1a4e, got %q", got)
+	}
+}
@@ -0,0 +1,239 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"math"
+	"runtime/metrics"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/runtime"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+const (
+	// runtimeScopeName is the instrumentation scope of the GC and process metrics
+	runtimeScopeName = "go-observability/runtime"
+	// runtimeReadInterval is the minimum interval between two reads of the Go runtime statistics
+	runtimeReadInterval = 5 * time.Second
+
+	gcCyclesMetric = "/gc/cycles/total:gc-cycles"
+	gcPausesMetric = "/sched/pauses/total/gc:seconds"
+)
+
+// newRuntimeProducer returns the producer attached to the built-in readers for the runtime
+// histograms (scheduler latency, GC pauses), or nil when runtime metrics are disabled
+func newRuntimeProducer(cfg BaseConfig) sdkmetric.Producer {
+	if !cfg.MetricsRuntimeEnabled {
+		return nil
+	}
+	return multiProducer{
+		runtime.NewProducer(),
+		newGCProducer(),
+	}
+}
+
+// startRuntimeMetrics registers the Go runtime and process instruments enabled in cfg on mp
+func startRuntimeMetrics(cfg BaseConfig, mp metric.MeterProvider) {
+	if cfg.MetricsRuntimeEnabled {
+		// Goroutines, memory classes, GC goal, GOGC and GOMAXPROCS
+		if err := runtime.Start(
+			runtime.WithMeterProvider(mp),
+			runtime.WithMinimumReadMemStatsInterval(runtimeReadInterval),
+		); err != nil {
+			otel.Handle(err)
+		}
+	}
+	if cfg.MetricsProcessEnabled {
+		if err := registerProcessMetrics(mp.Meter(runtimeScopeName)); err != nil {
+			otel.Handle(err)
+		}
+	}
+}
+
+// multiProducer combines several producers into one
+type multiProducer []sdkmetric.Producer
+
+// Produce implements sdkmetric.Producer, returning what every producer could provide
+func (m multiProducer) Produce(ctx context.Context) ([]metricdata.ScopeMetrics, error) {
+	var (
+		scopes []metricdata.ScopeMetrics
+		errs   []error
+	)
+	for _, p := range m {
+		produced, err := p.Produce(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		scopes = append(scopes, produced...)
+	}
+	return scopes, errors.Join(errs...)
+}
+
+// gcProducer reports the GC cycle count and the GC pause histogram from runtime/metrics
+type gcProducer struct {
+	mu      sync.Mutex
+	start   time.Time
+	samples []metrics.Sample
+}
+
+func newGCProducer() *gcProducer {
+	return &gcProducer{
+		start:   time.Now(),
+		samples: []metrics.Sample{{Name: gcCyclesMetric}, {Name: gcPausesMetric}},
+	}
+}
+
+// Produce implements sdkmetric.Producer
+func (p *gcProducer) Produce(context.Context) ([]metricdata.ScopeMetrics, error) {
+	p.mu.Lock()
+	metrics.Read(p.samples)
+	now := time.Now()
+	var (
+		cycles int64
+		pauses metricdata.HistogramDataPoint[float64]
+		ok     bool
+	)
+	if p.samples[0].Value.Kind() == metrics.KindUint64 {
+		cycles = int64(p.samples[0].Value.Uint64())
+	}
+	if p.samples[1].Value.Kind() == metrics.KindFloat64Histogram {
+		pauses, ok = runtimeHistogramDataPoint(p.samples[1].Value.Float64Histogram())
+	}
+	p.mu.Unlock()
+
+	gcMetrics := []metricdata.Metrics{{
+		Name:        "go.gc.count",
+		Description: "Number of completed GC cycles",
+		Unit:        "{gc_cycle}",
+		Data: metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints:  []metricdata.DataPoint[int64]{{StartTime: p.start, Time: now, Value: cycles}},
+		},
+	}}
+	if ok {
+		pauses.StartTime, pauses.Time = p.start, now
+		gcMetrics = append(gcMetrics, metricdata.Metrics{
+			Name:        "go.gc.pause.duration",
+			Description: "Distribution of the stop-the-world pauses caused by the garbage collector",
+			Unit:        "s",
+			Data: metricdata.Histogram[float64]{
+				Temporality: metricdata.CumulativeTemporality,
+				DataPoints:  []metricdata.HistogramDataPoint[float64]{pauses},
+			},
+		})
+	}
+
+	return []metricdata.ScopeMetrics{{
+		Scope:   instrumentation.Scope{Name: runtimeScopeName},
+		Metrics: gcMetrics,
+	}}, nil
+}
+
+// runtimeHistogramDataPoint converts a runtime/metrics histogram into an explicit bucket data
+// point. The sum is estimated from the bucket midpoints since the runtime does not record it.
+func runtimeHistogramDataPoint(h *metrics.Float64Histogram) (metricdata.HistogramDataPoint[float64], bool) {
+	if h == nil || len(h.Buckets) < 2 || len(h.Counts) != len(h.Buckets)-1 {
+		return metricdata.HistogramDataPoint[float64]{}, false
+	}
+
+	// Runtime buckets are [Buckets[i], Buckets[i+1]); OTel only keeps the inner boundaries and
+	// implies the -Inf/+Inf edges
+	bounds := h.Buckets[1 : len(h.Buckets)-1]
+	dp := metricdata.HistogramDataPoint[float64]{
+		Bounds:       append([]float64(nil), bounds...),
+		BucketCounts: append([]uint64(nil), h.Counts...),
+		Attributes:   *attribute.EmptySet(),
+	}
+	for i, count := range h.Counts {
+		if count == 0 {
+			continue
+		}
+		dp.Count += count
+		low, high := h.Buckets[i], h.Buckets[i+1]
+		switch {
+		case math.IsInf(low, -1):
+			dp.Sum += high * float64(count)
+		case math.IsInf(high, 1):
+			dp.Sum += low * float64(count)
+		default:
+			dp.Sum += (low + high) / 2 * float64(count)
+		}
+	}
+	return dp, true
+}
+
+// registerProcessMetrics registers CPU time, memory and file descriptor instruments; values the
+// platform cannot provide are not observed
+func registerProcessMetrics(meter metric.Meter) error {
+	cpuTime, err := meter.Float64ObservableCounter(
+		"process.cpu.time",
+		metric.WithDescription("Total CPU seconds broken down by mode"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return err
+	}
+	memoryUsage, err := meter.Int64ObservableUpDownCounter(
+		"process.memory.usage",
+		metric.WithDescription("Resident set size of the process"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return err
+	}
+	memoryVirtual, err := meter.Int64ObservableUpDownCounter(
+		"process.memory.virtual",
+		metric.WithDescription("Virtual memory size of the process"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return err
+	}
+	openFDs, err := meter.Int64ObservableUpDownCounter(
+		"process.open_file_descriptor.count",
+		metric.WithDescription("Number of file descriptors in use by the process"),
+		metric.WithUnit("{file_descriptor}"),
+	)
+	if err != nil {
+		return err
+	}
+
+	userMode := metric.WithAttributes(attribute.String("cpu.mode", "user"))
+	systemMode := metric.WithAttributes(attribute.String("cpu.mode", "system"))
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		stats := readProcessStats()
+		if stats.hasCPU {
+			o.ObserveFloat64(cpuTime, stats.userCPU, userMode)
+			o.ObserveFloat64(cpuTime, stats.systemCPU, systemMode)
+		}
+		if stats.rss >= 0 {
+			o.ObserveInt64(memoryUsage, stats.rss)
+		}
+		if stats.virtual >= 0 {
+			o.ObserveInt64(memoryVirtual, stats.virtual)
+		}
+		if stats.openFDs >= 0 {
+			o.ObserveInt64(openFDs, stats.openFDs)
+		}
+		return nil
+	}, cpuTime, memoryUsage, memoryVirtual, openFDs)
+	return err
+}
+
+// processStats is a snapshot of the process resource usage; negative values are unknown
+type processStats struct {
+	hasCPU    bool
+	userCPU   float64
+	systemCPU float64
+	rss       int64
+	virtual   int64
+	openFDs   int64
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package observability
+
+// readProcessStats reports nothing on platforms without getrusage and /dev/fd
+func readProcessStats() processStats {
+	return processStats{rss: -1, virtual: -1, openFDs: -1}
+}
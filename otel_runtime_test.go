@@ -0,0 +1,140 @@
+package observability
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	goruntime "runtime"
+	"runtime/metrics"
+	"slices"
+	"strings"
+	"testing"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRuntimeHistogramDataPoint(t *testing.T) {
+	h := &metrics.Float64Histogram{
+		Buckets: []float64{math.Inf(-1), 0.001, 0.01, math.Inf(1)},
+		Counts:  []uint64{1, 2, 3},
+	}
+
+	dp, ok := runtimeHistogramDataPoint(h)
+	if !ok {
+		t.Fatal("expected a data point")
+	}
+	if !slices.Equal(dp.Bounds, []float64{0.001, 0.01}) {
+		t.Errorf("expected the inner boundaries, got %v", dp.Bounds)
+	}
+	if !slices.Equal(dp.BucketCounts, []uint64{1, 2, 3}) {
+		t.Errorf("expected the runtime counts, got %v", dp.BucketCounts)
+	}
+	if dp.Count != 6 {
+		t.Errorf("expected count 6, got %d", dp.Count)
+	}
+	// -Inf bucket counts at its upper bound, +Inf at its lower bound, others at the midpoint
+	if want := 0.001 + 2*0.0055 + 3*0.01; math.Abs(dp.Sum-want) > 1e-12 {
+		t.Errorf("expected sum %v, got %v", want, dp.Sum)
+	}
+
+	if _, ok := runtimeHistogramDataPoint(&metrics.Float64Histogram{Buckets: []float64{0}}); ok {
+		t.Error("expected malformed histograms to be rejected")
+	}
+}
+
+func TestGCProducer(t *testing.T) {
+	goruntime.GC()
+
+	scopes, err := newGCProducer().Produce(context.Background())
+	if err != nil {
+		t.Fatalf("Produce failed: %v", err)
+	}
+	if len(scopes) != 1 {
+		t.Fatalf("expected one scope, got %d", len(scopes))
+	}
+
+	byName := map[string]metricdata.Metrics{}
+	for _, m := range scopes[0].Metrics {
+		byName[m.Name] = m
+	}
+	count, ok := byName["go.gc.count"].Data.(metricdata.Sum[int64])
+	if !ok || len(count.DataPoints) != 1 || count.DataPoints[0].Value < 1 {
+		t.Errorf("expected at least one GC cycle, got %+v", byName["go.gc.count"].Data)
+	}
+	pauses, ok := byName["go.gc.pause.duration"].Data.(metricdata.Histogram[float64])
+	if !ok || len(pauses.DataPoints) != 1 || pauses.DataPoints[0].Count < 1 {
+		t.Errorf("expected GC pauses, got %+v", byName["go.gc.pause.duration"].Data)
+	}
+}
+
+func TestInitOtel_RuntimeMetrics(t *testing.T) {
+	instruments := []string{"go.goroutine.count", "go.memory.used", "process.cpu.time"}
+
+	tests := []struct {
+		name    string
+		runtime bool
+		process bool
+		want    []string
+	}{
+		{name: "Enabled", runtime: true, process: true, want: instruments},
+		{name: "Runtime Only", runtime: true, want: []string{"go.goroutine.count", "go.memory.used"}},
+		{name: "Disabled", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := sdkmetric.NewManualReader()
+			cfg := BaseConfig{
+				ServiceName:           "runtime-service",
+				MetricsRuntimeEnabled: tt.runtime,
+				MetricsProcessEnabled: tt.process,
+			}
+			tel, err := InitOtelWithOptions(cfg,
+				WithoutGlobals(),
+				WithSpanExporter(tracetest.NewInMemoryExporter()),
+				WithMetricReader(reader),
+			)
+			if err != nil {
+				t.Fatalf("InitOtelWithOptions failed: %v", err)
+			}
+			defer func() { _ = tel.Shutdown(context.Background()) }()
+
+			for _, name := range instruments {
+				_, found := findMetric(t, reader, name)
+				if want := slices.Contains(tt.want, name); found != want {
+					t.Errorf("%s: expected found=%v, got %v", name, want, found)
+				}
+			}
+		})
+	}
+}
+
+func TestInitOtel_RuntimeHistogramsScrape(t *testing.T) {
+	cfg := BaseConfig{
+		ServiceName:           "runtime-pull-service",
+		MetricsMode:           "pull",
+		MetricsPath:           "/metrics",
+		MetricsRuntimeEnabled: true,
+	}
+	tel, err := InitOtelWithOptions(cfg, WithoutGlobals(), WithSpanExporter(tracetest.NewInMemoryExporter()))
+	if err != nil {
+		t.Fatalf("InitOtelWithOptions failed: %v", err)
+	}
+	defer func() { _ = tel.Shutdown(context.Background()) }()
+
+	resp, err := http.Get("http://" + tel.MetricsAddr() + "/metrics")
+	if err != nil {
+		t.Fatalf("failed to scrape metrics: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, _ := io.ReadAll(resp.Body)
+
+	for _, name := range []string{"go_schedule_duration_seconds_bucket", "go_gc_pause_duration_seconds_bucket", "go_gc_count"} {
+		if !strings.Contains(string(body), name) {
+			t.Errorf("expected %s in the scrape", name)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+//go:build unix
+
+package observability
+
+import (
+	"bytes"
+	"os"
+	"strconv"
+	"syscall"
+)
+
+// readProcessStats reads CPU time from getrusage, memory from /proc/self/statm (Linux only) and
+// counts the entries of /dev/fd
+func readProcessStats() processStats {
+	stats := processStats{rss: -1, virtual: -1, openFDs: -1}
+
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err == nil {
+		stats.hasCPU = true
+		stats.userCPU = float64(usage.Utime.Nano()) / 1e9
+		stats.systemCPU = float64(usage.Stime.Nano()) / 1e9
+	}
+
+	// statm holds sizes in pages: total program size, then resident set size
+	if data, err := os.ReadFile("/proc/self/statm"); err == nil {
+		fields := bytes.Fields(data)
+		if len(fields) >= 2 {
+			pageSize := int64(os.Getpagesize())
+			if pages, err := strconv.ParseInt(string(fields[0]), 10, 64); err == nil {
+				stats.virtual = pages * pageSize
+			}
+			if pages, err := strconv.ParseInt(string(fields[1]), 10, 64); err == nil {
+				stats.rss = pages * pageSize
+			}
+		}
+	}
+
+	if entries, err := os.ReadDir("/dev/fd"); err == nil {
+		// Reading the directory opens one descriptor that is not the process's own
+		stats.openFDs = int64(len(entries)) - 1
+	}
+
+	return stats
+}
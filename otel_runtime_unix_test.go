@@ -0,0 +1,29 @@
+//go:build unix
+
+package observability
+
+import (
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestReadProcessStats(t *testing.T) {
+	f, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", os.DevNull, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	stats := readProcessStats()
+	if !stats.hasCPU || stats.userCPU+stats.systemCPU <= 0 {
+		t.Errorf("expected CPU time, got %+v", stats)
+	}
+	if stats.openFDs < 4 {
+		// stdin, stdout, stderr and the file opened above
+		t.Errorf("expected at least 4 open file descriptors, got %d", stats.openFDs)
+	}
+	if runtime.GOOS == "linux" && (stats.rss <= 0 || stats.virtual < stats.rss) {
+		t.Errorf("expected resident and virtual memory from /proc, got rss=%d virtual=%d", stats.rss, stats.virtual)
+	}
+}
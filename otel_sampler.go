@@ -0,0 +1,142 @@
+package observability
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// newTraceSampler builds the sampler selected by TracesSampler (OTEL_TRACES_SAMPLER), defaulting to
+// parentbased_traceidratio. Ratio-based samplers use OtelTracingSampleRate, and TracesSamplerRules
+// override the rate for matching routes or RPCs wherever the ratio (or the root decision) applies.
+func newTraceSampler(cfg BaseConfig) (sdktrace.Sampler, error) {
+	var root sdktrace.Sampler = sdktrace.TraceIDRatioBased(cfg.OtelTracingSampleRate)
+
+	strategy := strings.ToLower(strings.TrimSpace(cfg.TracesSampler))
+	switch strategy {
+	case "always_on", "parentbased_always_on":
+		root = sdktrace.AlwaysSample()
+	case "always_off", "parentbased_always_off":
+		root = sdktrace.NeverSample()
+	}
+
+	rules, err := parseSamplingRules(cfg.TracesSamplerRules)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) > 0 {
+		root = &ruleSampler{rules: rules, fallback: root}
+	}
+
+	switch strategy {
+	case "always_on", "always_off", "traceidratio":
+		return root, nil
+	default:
+		// Respect the parent's decision so upstream-sampled traces stay complete
+		return sdktrace.ParentBased(root), nil
+	}
+}
+
+// samplingRule samples spans matching a route or RPC at a fixed rate
+type samplingRule struct {
+	// pattern is matched against the HTTP route, URL path, "/package.Service/Method" and the span
+	// name; a trailing "*" matches any suffix
+	pattern string
+	rate    float64
+	sampler sdktrace.Sampler
+}
+
+// matches reports whether the rule applies to any of the candidate values
+func (r samplingRule) matches(candidates []string) bool {
+	prefix, wildcard := strings.CutSuffix(r.pattern, "*")
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+		if wildcard && strings.HasPrefix(candidate, prefix) {
+			return true
+		}
+		if candidate == r.pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// parseSamplingRules parses "pattern=rate,..." (e.g. "/healthz=0,/checkout=1") in declaration order
+func parseSamplingRules(raw string) ([]samplingRule, error) {
+	var rules []samplingRule
+	for _, item := range strings.Split(raw, ",") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		pattern, rateStr, ok := strings.Cut(item, "=")
+		pattern = strings.TrimSpace(pattern)
+		if !ok || pattern == "" {
+			return nil, fmt.Errorf("invalid OTEL_TRACES_SAMPLER_RULES: %q (must be 'pattern=rate' pairs separated by ',')", item)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(rateStr), 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("invalid OTEL_TRACES_SAMPLER_RULES: %q (rate must be between 0 and 1)", item)
+		}
+		rules = append(rules, samplingRule{
+			pattern: pattern,
+			rate:    rate,
+			sampler: sdktrace.TraceIDRatioBased(rate),
+		})
+	}
+	return rules, nil
+}
+
+// ruleSampler applies the first matching rule's rate and delegates other spans to fallback
+type ruleSampler struct {
+	rules    []samplingRule
+	fallback sdktrace.Sampler
+}
+
+// ShouldSample implements sdktrace.Sampler
+func (s *ruleSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	candidates := samplingCandidates(p)
+	for _, rule := range s.rules {
+		if rule.matches(candidates) {
+			return rule.sampler.ShouldSample(p)
+		}
+	}
+	return s.fallback.ShouldSample(p)
+}
+
+// Description implements sdktrace.Sampler
+func (s *ruleSampler) Description() string {
+	rules := make([]string, 0, len(s.rules))
+	for _, rule := range s.rules {
+		rules = append(rules, fmt.Sprintf("%s=%g", rule.pattern, rule.rate))
+	}
+	return fmt.Sprintf("RuleBased{rules:[%s],fallback:%s}", strings.Join(rules, ","), s.fallback.Description())
+}
+
+// samplingCandidates returns the values sampling rules are matched against: the HTTP route and
+// path set by GinTracing, the gRPC method set by the gRPC tracing interceptors, and the span name
+func samplingCandidates(p sdktrace.SamplingParameters) []string {
+	var route, path, service, method string
+	for _, attr := range p.Attributes {
+		switch attr.Key {
+		case semconv.HTTPRouteKey:
+			route = attr.Value.AsString()
+		case semconv.URLPathKey:
+			path = attr.Value.AsString()
+		case semconv.RPCServiceKey:
+			service = attr.Value.AsString()
+		case semconv.RPCMethodKey:
+			method = attr.Value.AsString()
+		}
+	}
+
+	candidates := []string{route, path, p.Name}
+	if service != "" && method != "" {
+		candidates = append(candidates, "/"+service+"/"+method)
+	}
+	return candidates
+}
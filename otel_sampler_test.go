@@ -0,0 +1,179 @@
+package observability
+
+import (
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewTraceSampler(t *testing.T) {
+	tests := []struct {
+		sampler string
+		want    string
+	}{
+		{"", "ParentBased{root:TraceIDRatioBased{0.5}"},
+		{"traceidratio", "TraceIDRatioBased{0.5}"},
+		{"always_on", "AlwaysOnSampler"},
+		{"always_off", "AlwaysOffSampler"},
+		{"parentbased_always_on", "ParentBased{root:AlwaysOnSampler"},
+		{"parentbased_always_off", "ParentBased{root:AlwaysOffSampler"},
+		{"PARENTBASED_TRACEIDRATIO", "ParentBased{root:TraceIDRatioBased{0.5}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sampler, func(t *testing.T) {
+			sampler, err := newTraceSampler(BaseConfig{TracesSampler: tt.sampler, OtelTracingSampleRate: 0.5})
+			if err != nil {
+				t.Fatalf("newTraceSampler failed: %v", err)
+			}
+			if got := sampler.Description(); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("expected sampler %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("Invalid Rules", func(t *testing.T) {
+		if _, err := newTraceSampler(BaseConfig{TracesSamplerRules: "/healthz"}); err == nil {
+			t.Error("expected an error for rules without a rate")
+		}
+	})
+}
+
+func TestTraceSamplerRules(t *testing.T) {
+	sampler, err := newTraceSampler(BaseConfig{
+		TracesSampler:         "parentbased_traceidratio",
+		OtelTracingSampleRate: 0,
+		TracesSamplerRules:    "/healthz=0, /checkout=1, /orders.v1.OrderService/*=1, GET /admin=1",
+	})
+	if err != nil {
+		t.Fatalf("newTraceSampler failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		params sdktrace.SamplingParameters
+		want   sdktrace.SamplingDecision
+	}{
+		{
+			name: "HTTP Route Sampled",
+			params: sdktrace.SamplingParameters{
+				Name:       "POST /checkout",
+				Attributes: []attribute.KeyValue{semconv.HTTPRoute("/checkout")},
+			},
+			want: sdktrace.RecordAndSample,
+		},
+		{
+			name: "URL Path Sampled",
+			params: sdktrace.SamplingParameters{
+				Name:       "request",
+				Attributes: []attribute.KeyValue{semconv.URLPath("/checkout")},
+			},
+			want: sdktrace.RecordAndSample,
+		},
+		{
+			name: "Health Check Dropped",
+			params: sdktrace.SamplingParameters{
+				Name:       "GET /healthz",
+				Attributes: []attribute.KeyValue{semconv.HTTPRoute("/healthz")},
+			},
+			want: sdktrace.Drop,
+		},
+		{
+			name: "RPC Wildcard Sampled",
+			params: sdktrace.SamplingParameters{
+				Name: "orders.v1.OrderService/Create",
+				Attributes: []attribute.KeyValue{
+					semconv.RPCService("orders.v1.OrderService"),
+					semconv.RPCMethod("Create"),
+				},
+			},
+			want: sdktrace.RecordAndSample,
+		},
+		{
+			name:   "Span Name Sampled",
+			params: sdktrace.SamplingParameters{Name: "GET /admin"},
+			want:   sdktrace.RecordAndSample,
+		},
+		{
+			name: "Unmatched Uses Ratio",
+			params: sdktrace.SamplingParameters{
+				Name:       "GET /products",
+				Attributes: []attribute.KeyValue{semconv.HTTPRoute("/products")},
+			},
+			want: sdktrace.Drop,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.params.TraceID = trace.TraceID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+			if got := sampler.ShouldSample(tt.params).Decision; got != tt.want {
+				t.Errorf("expected decision %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("Sampled Parent Wins Over Rule", func(t *testing.T) {
+		parent := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{0x01},
+			SpanID:     trace.SpanID{0x01},
+			TraceFlags: trace.FlagsSampled,
+		})
+		params := sdktrace.SamplingParameters{
+			ParentContext: trace.ContextWithSpanContext(t.Context(), parent),
+			TraceID:       parent.TraceID(),
+			Name:          "GET /healthz",
+			Attributes:    []attribute.KeyValue{semconv.HTTPRoute("/healthz")},
+		}
+		if got := sampler.ShouldSample(params).Decision; got != sdktrace.RecordAndSample {
+			t.Errorf("expected the parent's decision to be kept, got %v", got)
+		}
+	})
+
+	t.Run("Description", func(t *testing.T) {
+		want := "ParentBased{root:RuleBased{rules:[/healthz=0,/checkout=1,/orders.v1.OrderService/*=1,GET /admin=1],fallback:TraceIDRatioBased{0}}"
+		if got := sampler.Description(); !strings.HasPrefix(got, want) {
+			t.Errorf("expected description prefix %q, got %q", want, got)
+		}
+	})
+}
+
+func TestParseSamplingRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    int
+		wantErr bool
+	}{
+		{name: "Empty", raw: "", want: 0},
+		{name: "Blank Items", raw: " , /healthz=0 ,", want: 1},
+		{name: "Multiple", raw: "/healthz=0,/checkout=1,/search=0.25", want: 3},
+		{name: "Missing Rate", raw: "/healthz", wantErr: true},
+		{name: "Missing Pattern", raw: "=0.5", wantErr: true},
+		{name: "Rate Not A Number", raw: "/healthz=never", wantErr: true},
+		{name: "Rate Above One", raw: "/checkout=2", wantErr: true},
+		{name: "Rate Below Zero", raw: "/checkout=-0.1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := parseSamplingRules(tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error for %q", tt.raw)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSamplingRules failed: %v", err)
+			}
+			if len(rules) != tt.want {
+				t.Errorf("expected %d rules, got %d", tt.want, len(rules))
+			}
+		})
+	}
+}
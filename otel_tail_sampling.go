@@ -0,0 +1,290 @@
+package observability
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// tailDropReasonSampledOut marks spans of traces that were neither interesting nor ratio-sampled
+	tailDropReasonSampledOut = "sampled_out"
+	// tailDropReasonBufferFull marks spans dropped because OTEL_TAIL_SAMPLING_MAX_SPANS was reached
+	tailDropReasonBufferFull = "buffer_full"
+
+	// tailDefaultMaxSpans bounds the buffer when the config was not loaded through LoadCfg
+	tailDefaultMaxSpans = 10000
+
+	// tailDefaultMaxTraceAge bounds the buffering time when the config was not loaded through LoadCfg
+	tailDefaultMaxTraceAge = 30 * time.Second
+
+	// tailDecisionCacheSize is the number of recent trace decisions kept for spans that end after
+	// their local root
+	tailDecisionCacheSize = 4096
+)
+
+// tailSamplingProcessor buffers the local spans of each trace until its local root ends, then
+// forwards the whole trace to next when any span failed or was slow, or when the trace ID is
+// ratio-sampled. Everything else is dropped and counted.
+type tailSamplingProcessor struct {
+	next      sdktrace.SpanProcessor
+	ratio     sdktrace.Sampler
+	threshold time.Duration
+	maxSpans  int
+	maxAge    time.Duration
+	dropped   metric.Int64Counter
+
+	mu       sync.Mutex
+	traces   map[trace.TraceID]*tailTrace
+	buffered int
+	// order lists the buffered traces by the time their first span was buffered, oldest first
+	order []tailTraceEntry
+	// decided remembers recent decisions, in insertion order in decidedRing
+	decided     map[trace.TraceID]bool
+	decidedRing []trace.TraceID
+	decidedNext int
+}
+
+// tailTrace holds the ended spans of a trace whose local root is still running
+type tailTrace struct {
+	spans []sdktrace.ReadOnlySpan
+	// keep is set once any span of the trace failed or exceeded the latency threshold
+	keep bool
+	// since is when the first span of the trace was buffered
+	since time.Time
+}
+
+// tailTraceEntry is a buffered trace in p.order
+type tailTraceEntry struct {
+	traceID trace.TraceID
+	trace   *tailTrace
+}
+
+// newTailSamplingProcessor wraps next with the tail sampling settings from the config
+func newTailSamplingProcessor(next sdktrace.SpanProcessor, cfg BaseConfig) *tailSamplingProcessor {
+	p := &tailSamplingProcessor{
+		next:        next,
+		ratio:       sdktrace.TraceIDRatioBased(cfg.TailSamplingRate),
+		threshold:   time.Duration(cfg.TailSamplingLatencyThresholdMs) * time.Millisecond,
+		maxSpans:    cfg.TailSamplingMaxSpans,
+		maxAge:      time.Duration(cfg.TailSamplingMaxTraceAgeMs) * time.Millisecond,
+		traces:      make(map[trace.TraceID]*tailTrace),
+		decided:     make(map[trace.TraceID]bool),
+		decidedRing: make([]trace.TraceID, tailDecisionCacheSize),
+	}
+	if p.maxSpans <= 0 {
+		p.maxSpans = tailDefaultMaxSpans
+	}
+	if p.maxAge <= 0 {
+		p.maxAge = tailDefaultMaxTraceAge
+	}
+
+	p.useMeterProvider(otel.GetMeterProvider())
+
+	return p
+}
+
+// useMeterProvider creates the drop counter on mp. It must be called before spans are processed.
+func (p *tailSamplingProcessor) useMeterProvider(mp metric.MeterProvider) {
+	var err error
+	if p.dropped, err = mp.Meter("tail-sampling").Int64Counter(
+		"tail_sampling.dropped_spans",
+		metric.WithDescription("Number of spans dropped by the tail sampling processor"),
+		metric.WithUnit("{span}"),
+	); err != nil {
+		otel.Handle(err)
+	}
+}
+
+// OnStart implements sdktrace.SpanProcessor
+func (p *tailSamplingProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd implements sdktrace.SpanProcessor
+func (p *tailSamplingProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	sc := s.SpanContext()
+	if !sc.IsSampled() {
+		// Not exported by the batch processor either
+		return
+	}
+
+	traceID := sc.TraceID()
+	interesting := p.interesting(s)
+	localRoot := !s.Parent().IsValid() || s.Parent().IsRemote()
+
+	// Decide the traces whose local root did not end in time (leaked or long-lived spans), so
+	// they do not hold buffer space forever
+	p.flushStale(time.Now())
+
+	p.mu.Lock()
+
+	// The local root already ended: follow its decision
+	if keep, ok := p.decided[traceID]; ok {
+		p.mu.Unlock()
+		p.export([]sdktrace.ReadOnlySpan{s}, keep || interesting)
+		return
+	}
+
+	t := p.traces[traceID]
+
+	if !localRoot {
+		if p.buffered >= p.maxSpans {
+			if t != nil && interesting {
+				t.keep = true
+			}
+			p.mu.Unlock()
+			// Errors and slow spans are still exported when the buffer is full
+			if interesting {
+				p.next.OnEnd(s)
+			} else {
+				p.drop(1, tailDropReasonBufferFull)
+			}
+			return
+		}
+		if t == nil {
+			t = &tailTrace{since: time.Now()}
+			p.traces[traceID] = t
+			p.order = append(p.order, tailTraceEntry{traceID: traceID, trace: t})
+		}
+		t.spans = append(t.spans, s)
+		t.keep = t.keep || interesting
+		p.buffered++
+		p.mu.Unlock()
+		return
+	}
+
+	// The local root ended: decide for the whole trace
+	spans := []sdktrace.ReadOnlySpan{s}
+	keep := interesting
+	if t != nil {
+		spans = append(t.spans, s)
+		keep = keep || t.keep
+		p.buffered -= len(t.spans)
+		delete(p.traces, traceID)
+	}
+	keep = keep || p.sampled(traceID)
+	p.remember(traceID, keep)
+	p.mu.Unlock()
+
+	p.export(spans, keep)
+}
+
+// Shutdown implements sdktrace.SpanProcessor
+func (p *tailSamplingProcessor) Shutdown(ctx context.Context) error {
+	p.flushPending()
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush implements sdktrace.SpanProcessor. Traces whose local root is still running are
+// decided early with the spans buffered so far.
+func (p *tailSamplingProcessor) ForceFlush(ctx context.Context) error {
+	p.flushPending()
+	return p.next.ForceFlush(ctx)
+}
+
+// flushPending decides every buffered trace
+func (p *tailSamplingProcessor) flushPending() {
+	p.mu.Lock()
+	pending := p.traces
+	p.traces = make(map[trace.TraceID]*tailTrace)
+	p.buffered = 0
+	p.order = nil
+	decisions := make(map[trace.TraceID]bool, len(pending))
+	for traceID, t := range pending {
+		keep := t.keep || p.sampled(traceID)
+		p.remember(traceID, keep)
+		decisions[traceID] = keep
+	}
+	p.mu.Unlock()
+
+	for traceID, t := range pending {
+		p.export(t.spans, decisions[traceID])
+	}
+}
+
+// flushStale decides the traces buffered for longer than maxAge, like flushPending
+func (p *tailSamplingProcessor) flushStale(now time.Time) {
+	p.mu.Lock()
+	var stale []*tailTrace
+	for len(p.order) > 0 {
+		entry := p.order[0]
+		if p.traces[entry.traceID] != entry.trace {
+			// Already decided by its local root
+			p.order = p.order[1:]
+			continue
+		}
+		if now.Sub(entry.trace.since) <= p.maxAge {
+			break
+		}
+		p.order = p.order[1:]
+		delete(p.traces, entry.traceID)
+		p.buffered -= len(entry.trace.spans)
+		entry.trace.keep = entry.trace.keep || p.sampled(entry.traceID)
+		p.remember(entry.traceID, entry.trace.keep)
+		stale = append(stale, entry.trace)
+	}
+	p.mu.Unlock()
+
+	for _, t := range stale {
+		p.export(t.spans, t.keep)
+	}
+}
+
+// interesting reports whether the span failed or exceeded the latency threshold
+func (p *tailSamplingProcessor) interesting(s sdktrace.ReadOnlySpan) bool {
+	if s.Status().Code == codes.Error {
+		return true
+	}
+	return p.threshold > 0 && s.EndTime().Sub(s.StartTime()) > p.threshold
+}
+
+// sampled applies the ratio to traces that are not otherwise kept; the decision is derived
+// from the trace ID, so every service using the same ratio agrees
+func (p *tailSamplingProcessor) sampled(traceID trace.TraceID) bool {
+	result := p.ratio.ShouldSample(sdktrace.SamplingParameters{TraceID: traceID})
+	return result.Decision == sdktrace.RecordAndSample
+}
+
+// remember records a trace decision, evicting the oldest one once the cache is full.
+// The caller must hold p.mu.
+func (p *tailSamplingProcessor) remember(traceID trace.TraceID, keep bool) {
+	if _, ok := p.decided[traceID]; ok {
+		// Already in the ring: a second copy would evict this live decision early
+		p.decided[traceID] = keep
+		return
+	}
+	if oldest := p.decidedRing[p.decidedNext]; oldest.IsValid() {
+		delete(p.decided, oldest)
+	}
+	p.decidedRing[p.decidedNext] = traceID
+	p.decidedNext = (p.decidedNext + 1) % len(p.decidedRing)
+	p.decided[traceID] = keep
+}
+
+// export forwards the spans to the next processor, or counts them as dropped
+func (p *tailSamplingProcessor) export(spans []sdktrace.ReadOnlySpan, keep bool) {
+	if !keep {
+		p.drop(len(spans), tailDropReasonSampledOut)
+		return
+	}
+	for _, s := range spans {
+		p.next.OnEnd(s)
+	}
+}
+
+// drop counts dropped spans by reason
+func (p *tailSamplingProcessor) drop(n int, reason string) {
+	if p.dropped == nil || n == 0 {
+		return
+	}
+	p.dropped.Add(context.Background(), int64(n),
+		metric.WithAttributes(attribute.String("reason", reason)))
+}
@@ -0,0 +1,260 @@
+package observability
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// setupTailSampling returns a tracer whose spans pass through a tail sampling processor into a recorder
+func setupTailSampling(t *testing.T, cfg BaseConfig) (trace.Tracer, *tailSamplingProcessor, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	t.Helper()
+
+	reader := setupTestMetrics(t)
+	recorder := tracetest.NewSpanRecorder()
+	processor := newTailSamplingProcessor(recorder, cfg)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(processor))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	return tp.Tracer("tail-sampling-test"), processor, recorder, reader
+}
+
+// droppedSpans returns the tail sampling drop counter value for reason
+func droppedSpans(t *testing.T, reader *sdkmetric.ManualReader, reason string) int64 {
+	t.Helper()
+
+	m, ok := findMetric(t, reader, "tail_sampling.dropped_spans")
+	if !ok {
+		return 0
+	}
+	sum, ok := m.Data.(metricdata.Sum[int64])
+	if !ok {
+		t.Fatalf("expected an int64 sum, got %T", m.Data)
+	}
+	var total int64
+	for _, dp := range sum.DataPoints {
+		if v, ok := dp.Attributes.Value(attribute.Key("reason")); ok && v.AsString() == reason {
+			total += dp.Value
+		}
+	}
+	return total
+}
+
+func TestTailSamplingProcessor(t *testing.T) {
+	base := BaseConfig{
+		TailSamplingEnabled:            true,
+		TailSamplingRate:               0,
+		TailSamplingLatencyThresholdMs: 1000,
+		TailSamplingMaxSpans:           100,
+	}
+
+	t.Run("Drops Healthy Fast Traces", func(t *testing.T) {
+		tracer, _, recorder, reader := setupTailSampling(t, base)
+
+		ctx, root := tracer.Start(context.Background(), "root")
+		_, child := tracer.Start(ctx, "child")
+		child.End()
+		root.End()
+
+		if got := len(recorder.Ended()); got != 0 {
+			t.Errorf("expected no exported spans, got %d", got)
+		}
+		if got := droppedSpans(t, reader, tailDropReasonSampledOut); got != 2 {
+			t.Errorf("expected 2 sampled-out spans, got %d", got)
+		}
+	})
+
+	t.Run("Keeps Traces With Errors", func(t *testing.T) {
+		tracer, _, recorder, _ := setupTailSampling(t, base)
+
+		ctx, root := tracer.Start(context.Background(), "root")
+		_, child := tracer.Start(ctx, "child")
+		child.SetStatus(codes.Error, "boom")
+		child.End()
+
+		if got := len(recorder.Ended()); got != 0 {
+			t.Fatalf("expected spans to be buffered until the root ends, got %d exported", got)
+		}
+		root.End()
+
+		if got := len(recorder.Ended()); got != 2 {
+			t.Errorf("expected the whole trace to be exported, got %d spans", got)
+		}
+	})
+
+	t.Run("Keeps Slow Traces", func(t *testing.T) {
+		tracer, _, recorder, _ := setupTailSampling(t, base)
+
+		start := time.Now()
+		_, root := tracer.Start(context.Background(), "root", trace.WithTimestamp(start))
+		root.End(trace.WithTimestamp(start.Add(1500 * time.Millisecond)))
+
+		if got := len(recorder.Ended()); got != 1 {
+			t.Errorf("expected the slow trace to be exported, got %d spans", got)
+		}
+	})
+
+	t.Run("Ratio Samples The Rest", func(t *testing.T) {
+		cfg := base
+		cfg.TailSamplingRate = 1
+		tracer, _, recorder, _ := setupTailSampling(t, cfg)
+
+		ctx, root := tracer.Start(context.Background(), "root")
+		_, child := tracer.Start(ctx, "child")
+		child.End()
+		root.End()
+
+		if got := len(recorder.Ended()); got != 2 {
+			t.Errorf("expected the sampled trace to be exported, got %d spans", got)
+		}
+	})
+
+	t.Run("Remote Parent Is A Local Root", func(t *testing.T) {
+		tracer, _, recorder, _ := setupTailSampling(t, base)
+
+		remote := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{0x01},
+			SpanID:     trace.SpanID{0x01},
+			TraceFlags: trace.FlagsSampled,
+			Remote:     true,
+		})
+		ctx := trace.ContextWithRemoteSpanContext(context.Background(), remote)
+		_, server := tracer.Start(ctx, "server")
+		server.SetStatus(codes.Error, "failed")
+		server.End()
+
+		if got := len(recorder.Ended()); got != 1 {
+			t.Errorf("expected the server span to be exported, got %d spans", got)
+		}
+	})
+
+	t.Run("Late Spans Follow The Root Decision", func(t *testing.T) {
+		tracer, _, recorder, _ := setupTailSampling(t, base)
+
+		ctx, root := tracer.Start(context.Background(), "root")
+		_, late := tracer.Start(ctx, "async")
+		root.SetStatus(codes.Error, "failed")
+		root.End()
+		late.End()
+
+		if got := len(recorder.Ended()); got != 2 {
+			t.Errorf("expected the late span to be exported with its trace, got %d spans", got)
+		}
+	})
+
+	t.Run("Bounded Buffer", func(t *testing.T) {
+		cfg := base
+		cfg.TailSamplingMaxSpans = 1
+		tracer, processor, recorder, reader := setupTailSampling(t, cfg)
+
+		ctx, root := tracer.Start(context.Background(), "root")
+		for _, name := range []string{"first", "second"} {
+			_, child := tracer.Start(ctx, name)
+			child.End()
+		}
+		_, failed := tracer.Start(ctx, "failed")
+		failed.SetStatus(codes.Error, "boom")
+		failed.End()
+
+		if processor.buffered != 1 {
+			t.Errorf("expected 1 buffered span, got %d", processor.buffered)
+		}
+		if got := droppedSpans(t, reader, tailDropReasonBufferFull); got != 1 {
+			t.Errorf("expected 1 span dropped for a full buffer, got %d", got)
+		}
+
+		root.End()
+		// The failed span bypassed the full buffer, then the root and first span follow it
+		if got := len(recorder.Ended()); got != 3 {
+			t.Errorf("expected 3 exported spans, got %d", got)
+		}
+		if processor.buffered != 0 || len(processor.traces) != 0 {
+			t.Errorf("expected an empty buffer, got %d spans in %d traces", processor.buffered, len(processor.traces))
+		}
+	})
+
+	t.Run("ForceFlush Decides Pending Traces", func(t *testing.T) {
+		tracer, processor, recorder, _ := setupTailSampling(t, base)
+
+		ctx, root := tracer.Start(context.Background(), "root")
+		_, child := tracer.Start(ctx, "child")
+		child.SetStatus(codes.Error, "boom")
+		child.End()
+
+		if err := processor.ForceFlush(context.Background()); err != nil {
+			t.Fatalf("ForceFlush failed: %v", err)
+		}
+		if got := len(recorder.Ended()); got != 1 {
+			t.Errorf("expected the buffered span to be exported, got %d", got)
+		}
+
+		root.End()
+		if got := len(recorder.Ended()); got != 2 {
+			t.Errorf("expected the root to follow the flushed decision, got %d spans", got)
+		}
+	})
+
+	t.Run("Decides Stale Traces", func(t *testing.T) {
+		cfg := base
+		cfg.TailSamplingMaxTraceAgeMs = 50
+		tracer, processor, recorder, reader := setupTailSampling(t, cfg)
+
+		failedCtx, failedRoot := tracer.Start(context.Background(), "failed-root")
+		_, failed := tracer.Start(failedCtx, "failed")
+		failed.SetStatus(codes.Error, "boom")
+		failed.End()
+		healthyCtx, healthyRoot := tracer.Start(context.Background(), "healthy-root")
+		_, healthy := tracer.Start(healthyCtx, "healthy")
+		healthy.End()
+
+		// Any span ending after the max age decides the stale traces
+		time.Sleep(100 * time.Millisecond)
+		_, other := tracer.Start(context.Background(), "other")
+		other.End()
+
+		if got := len(recorder.Ended()); got != 1 {
+			t.Errorf("expected the stale failed span to be exported, got %d spans", got)
+		}
+		if got := droppedSpans(t, reader, tailDropReasonSampledOut); got != 2 {
+			t.Errorf("expected the stale healthy span and the other trace to be sampled out, got %d", got)
+		}
+		if processor.buffered != 0 || len(processor.traces) != 0 || len(processor.order) != 0 {
+			t.Errorf("expected an empty buffer, got %d spans in %d traces", processor.buffered, len(processor.traces))
+		}
+
+		failedRoot.End()
+		healthyRoot.End()
+		if got := len(recorder.Ended()); got != 2 {
+			t.Errorf("expected the roots to follow the stale decisions, got %d spans", got)
+		}
+	})
+
+	t.Run("Remembers A Trace Once", func(t *testing.T) {
+		_, processor, _, _ := setupTailSampling(t, base)
+
+		traceID := trace.TraceID{0x02}
+		processor.remember(traceID, false)
+		processor.remember(traceID, true)
+
+		count := 0
+		for _, id := range processor.decidedRing {
+			if id == traceID {
+				count++
+			}
+		}
+		if count != 1 {
+			t.Errorf("expected the trace ID in the decision ring once, got %d", count)
+		}
+		if !processor.decided[traceID] {
+			t.Error("expected the latest decision to be kept")
+		}
+	})
+}
@@ -0,0 +1,133 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/propagation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Telemetry is the handle returned by InitOtelWithOptions. It owns the providers and the
+// metrics server created for one service; Shutdown releases them.
+type Telemetry struct {
+	tracerProvider *sdktrace.TracerProvider
+	meterProvider  *sdkmetric.MeterProvider
+	// loggerProvider is nil unless LogsMode exports logs over OTLP
+	loggerProvider *sdklog.LoggerProvider
+	propagator     propagation.TextMapPropagator
+	// metricsServer is nil unless the Prometheus pull server is running
+	metricsServer   *http.Server
+	metricsAddr     string
+	metricsShutdown []func(context.Context) error
+	health          *HealthRegistry
+	promRegistry    *prometheus.Registry
+}
+
+// TracerProvider returns the SDK TracerProvider
+func (t *Telemetry) TracerProvider() *sdktrace.TracerProvider { return t.tracerProvider }
+
+// MeterProvider returns the SDK MeterProvider
+func (t *Telemetry) MeterProvider() *sdkmetric.MeterProvider { return t.meterProvider }
+
+// LoggerProvider returns the SDK LoggerProvider, or nil when logs are not exported over OTLP
+func (t *Telemetry) LoggerProvider() *sdklog.LoggerProvider { return t.loggerProvider }
+
+// Propagator returns the configured text map propagator
+func (t *Telemetry) Propagator() propagation.TextMapPropagator { return t.propagator }
+
+// MetricsAddr returns the address the metrics server listens on (useful with MetricsPort 0),
+// or "" when no metrics server is running
+func (t *Telemetry) MetricsAddr() string { return t.metricsAddr }
+
+// Health returns the registry behind /livez, /readyz and the health_check_status gauge
+func (t *Telemetry) Health() *HealthRegistry { return t.health }
+
+// PrometheusRegistry returns the private registry behind the Prometheus exporter. Collectors
+// registered on it are served by the pull server; nothing serves it in push-only mode.
+func (t *Telemetry) PrometheusRegistry() *prometheus.Registry { return t.promRegistry }
+
+// ForceFlush exports all buffered metrics, spans and log records
+func (t *Telemetry) ForceFlush(ctx context.Context) error {
+	return joinOtelErrors("otel force flush failures", t.forceFlush(ctx))
+}
+
+// forceFlush flushes every provider and returns the failures
+func (t *Telemetry) forceFlush(ctx context.Context) []string {
+	var errs []string
+
+	// ForceFlush Meter Provider to ensure all metrics are sent before shutdown
+	// This is especially important for short-lived jobs and batch processes
+	if t.meterProvider != nil {
+		if err := t.meterProvider.ForceFlush(ctx); err != nil {
+			errs = append(errs, fmt.Sprintf("meter provider force flush error: %v", err))
+		}
+	}
+
+	// ForceFlush Tracer Provider to ensure all traces are sent before shutdown
+	if err := t.tracerProvider.ForceFlush(ctx); err != nil {
+		errs = append(errs, fmt.Sprintf("tracer provider force flush error: %v", err))
+	}
+
+	// ForceFlush Logger Provider to ensure all log records are sent before shutdown
+	if t.loggerProvider != nil {
+		if err := t.loggerProvider.ForceFlush(ctx); err != nil {
+			errs = append(errs, fmt.Sprintf("logger provider force flush error: %v", err))
+		}
+	}
+
+	return errs
+}
+
+// Shutdown flushes and stops the providers and the metrics server
+func (t *Telemetry) Shutdown(ctx context.Context) error {
+	errs := t.forceFlush(ctx)
+
+	// Shutdown Metrics Server (if pull mode enabled)
+	if t.metricsServer != nil {
+		if err := t.metricsServer.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Sprintf("metrics server shutdown error: %v", err))
+		}
+	}
+
+	// Shutdown Tracer Provider
+	if err := t.tracerProvider.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Sprintf("tracer provider shutdown error: %v", err))
+	}
+
+	// Shutdown Meter Provider (nil when InitOtelWithOptions failed before creating it)
+	if t.meterProvider != nil {
+		if err := t.meterProvider.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Sprintf("meter provider shutdown error: %v", err))
+		}
+	}
+
+	// Shutdown Logger Provider
+	if t.loggerProvider != nil {
+		if err := t.loggerProvider.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Sprintf("logger provider shutdown error: %v", err))
+		}
+	}
+
+	// Shutdown push-specific resources
+	for _, shutdown := range t.metricsShutdown {
+		if err := shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Sprintf("push metrics shutdown error: %v", err))
+		}
+	}
+
+	return joinOtelErrors("otel shutdown failures", errs)
+}
+
+// joinOtelErrors combines flush or shutdown failures into one error
+func joinOtelErrors(prefix string, errs []string) error {
+	if len(errs) > 0 {
+		return fmt.Errorf("%s: %s", prefix, strings.Join(errs, "; "))
+	}
+	return nil
+}
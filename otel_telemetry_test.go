@@ -0,0 +1,129 @@
+package observability
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTelemetry(t *testing.T) {
+	cfg := BaseConfig{
+		ServiceName: "telemetry-service",
+		MetricsMode: "pull",
+		MetricsPath: "/metrics",
+		// Port 0 picks a free port, reported by MetricsAddr
+		MetricsPort: 0,
+	}
+
+	tel, err := InitOtelWithOptions(cfg, WithoutGlobals(), WithSpanExporter(tracetest.NewInMemoryExporter()))
+	if err != nil {
+		t.Fatalf("InitOtelWithOptions failed: %v", err)
+	}
+	defer func() { _ = tel.Shutdown(context.Background()) }()
+
+	if tel.TracerProvider() == nil || tel.MeterProvider() == nil || tel.Propagator() == nil {
+		t.Fatal("expected tracer provider, meter provider and propagator to be set")
+	}
+	if tel.LoggerProvider() != nil {
+		t.Error("expected no logger provider when logs are written to stdout")
+	}
+	if got := len(tel.Propagator().Fields()); got == 0 {
+		t.Error("expected the default propagator to inject fields")
+	}
+
+	addr := tel.MetricsAddr()
+	if addr == "" {
+		t.Fatal("expected the metrics server address")
+	}
+	resp, err := http.Get("http://" + addr + "/metrics")
+	if err != nil {
+		t.Fatalf("failed to scrape metrics: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	if err := tel.ForceFlush(context.Background()); err != nil {
+		t.Errorf("ForceFlush failed: %v", err)
+	}
+}
+
+func TestTelemetry_IsolatedInstances(t *testing.T) {
+	globalTracerProvider := otel.GetTracerProvider()
+	globalMeterProvider := otel.GetMeterProvider()
+
+	type instance struct {
+		tel      *Telemetry
+		exporter *tracetest.InMemoryExporter
+	}
+	var instances []instance
+	for _, name := range []string{"first-service", "second-service"} {
+		exporter := tracetest.NewInMemoryExporter()
+		tel, err := InitOtelWithOptions(BaseConfig{ServiceName: name, OtelTracingSampleRate: 1},
+			WithoutGlobals(),
+			WithSpanExporter(exporter),
+			WithMetricReader(sdkmetric.NewManualReader()),
+		)
+		if err != nil {
+			t.Fatalf("InitOtelWithOptions failed: %v", err)
+		}
+		t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+		instances = append(instances, instance{tel: tel, exporter: exporter})
+	}
+
+	if otel.GetTracerProvider() != globalTracerProvider || otel.GetMeterProvider() != globalMeterProvider {
+		t.Error("expected WithoutGlobals to leave the global providers untouched")
+	}
+	if instances[0].tel.TracerProvider() == instances[1].tel.TracerProvider() {
+		t.Fatal("expected each instance to own its tracer provider")
+	}
+
+	_, span := instances[0].tel.TracerProvider().Tracer("isolated").Start(context.Background(), "only-first")
+	span.End()
+
+	for i, inst := range instances {
+		if err := inst.tel.ForceFlush(context.Background()); err != nil {
+			t.Fatalf("ForceFlush failed: %v", err)
+		}
+		want := 0
+		if i == 0 {
+			want = 1
+		}
+		if got := len(inst.exporter.GetSpans()); got != want {
+			t.Errorf("instance %d: expected %d exported spans, got %d", i, want, got)
+		}
+	}
+}
+
+func TestTelemetry_TailSamplingMetricsWithoutGlobals(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	cfg := BaseConfig{
+		ServiceName:           "tail-isolated-service",
+		OtelTracingSampleRate: 1,
+		TailSamplingEnabled:   true,
+		TailSamplingRate:      0,
+		TailSamplingMaxSpans:  10,
+	}
+
+	tel, err := InitOtelWithOptions(cfg,
+		WithoutGlobals(),
+		WithSpanExporter(tracetest.NewInMemoryExporter()),
+		WithMetricReader(reader),
+	)
+	if err != nil {
+		t.Fatalf("InitOtelWithOptions failed: %v", err)
+	}
+	defer func() { _ = tel.Shutdown(context.Background()) }()
+
+	_, span := tel.TracerProvider().Tracer("isolated").Start(context.Background(), "dropped")
+	span.End()
+
+	if _, ok := findMetric(t, reader, "tail_sampling.dropped_spans"); !ok {
+		t.Error("expected the drop counter on the instance's MeterProvider")
+	}
+}
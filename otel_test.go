@@ -2,9 +2,19 @@ package observability
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"net"
+	"net/http"
+	"slices"
+	"strings"
 	"testing"
 	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap/zapcore"
 )
 
 func TestInitOtel(t *testing.T) {
@@ -174,6 +184,52 @@ func TestInitOtel(t *testing.T) {
 		_ = shutdown(ctx) // Ignore error as collector may not be running
 	})
 
+	t.Run("Init Success with gRPC Traces", func(t *testing.T) {
+		cfgTracesGRPC := cfg
+		cfgTracesGRPC.MetricsPort = 19096
+		cfgTracesGRPC.OtelEndpoint = "localhost:4317"
+		cfgTracesGRPC.TracesProtocol = "grpc"
+		cfgTracesGRPC.OtelExporterCompression = "gzip"
+
+		shutdown, err := InitOtel(cfgTracesGRPC)
+		if err != nil {
+			t.Fatalf("InitOtel with gRPC traces failed: %v", err)
+		}
+		if shutdown == nil {
+			t.Fatal("shutdown function is nil")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = shutdown(ctx) // Ignore error as collector may not be running
+	})
+
+	t.Run("Init Success with Tail Sampling", func(t *testing.T) {
+		cfgTail := cfg
+		cfgTail.MetricsPort = 19097
+		cfgTail.TailSamplingEnabled = true
+		cfgTail.TailSamplingRate = 0.05
+		cfgTail.TailSamplingLatencyThresholdMs = 500
+		cfgTail.TailSamplingMaxSpans = 100
+
+		shutdown, err := InitOtel(cfgTail)
+		if err != nil {
+			t.Fatalf("InitOtel with tail sampling failed: %v", err)
+		}
+		if shutdown == nil {
+			t.Fatal("shutdown function is nil")
+		}
+
+		ctx, span := GetTracer("test-tracer-tail").Start(context.Background(), "root")
+		_, child := GetTracer("test-tracer-tail").Start(ctx, "child")
+		child.End()
+		span.End()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = shutdown(ctx) // Ignore error as collector may not be running
+	})
+
 	t.Run("Init Success with Hybrid Mode - gRPC Protocol", func(t *testing.T) {
 		cfgHybridGRPC := cfg
 		cfgHybridGRPC.MetricsPort = 19095
@@ -205,6 +261,102 @@ func TestInitOtel(t *testing.T) {
 	})
 }
 
+func TestInitOtel_LogsExport(t *testing.T) {
+	for i, protocol := range []string{"http", "grpc"} {
+		t.Run(protocol, func(t *testing.T) {
+			cfg := BaseConfig{
+				ServiceName:           "test-otel-logs",
+				Version:               "1.0.0",
+				OtelEndpoint:          "localhost:4318",
+				OtelTracingSampleRate: 1.0,
+				MetricsPort:           19130 + i,
+				MetricsMode:           "pull",
+				MetricsPath:           "/metrics",
+				LogsMode:              "both",
+				LogsProtocol:          protocol,
+			}
+
+			shutdown, err := InitOtel(cfg)
+			if err != nil {
+				t.Fatalf("InitOtel with logs export failed: %v", err)
+			}
+
+			// Emit through the tee core; only over HTTP, as the gRPC exporter retries
+			// an unreachable collector until its own export timeout
+			if protocol == "http" {
+				NewLogger(&cfg).Info("exported log line")
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			_ = shutdown(ctx) // Ignore error as collector may not be running
+		})
+	}
+}
+
+func TestInitOtel_LogLevelEndpoint(t *testing.T) {
+	original := DefaultLogger()
+	defer SetDefaultLogger(original)
+
+	logger, _ := newObservedLogger()
+	SetDefaultLogger(logger)
+
+	tests := []struct {
+		name       string
+		enabled    bool
+		token      string
+		auth       string
+		wantStatus int
+		wantLevel  string
+	}{
+		{name: "Disabled", enabled: false, wantStatus: http.StatusNotFound, wantLevel: "debug"},
+		{name: "Enabled", enabled: true, wantStatus: http.StatusOK, wantLevel: "warn"},
+		{name: "Token Required", enabled: true, token: "s3cret", wantStatus: http.StatusUnauthorized, wantLevel: "debug"},
+		{name: "Token Provided", enabled: true, token: "s3cret", auth: "Bearer s3cret", wantStatus: http.StatusOK, wantLevel: "warn"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger.SetLevel(zapcore.DebugLevel)
+			cfg := BaseConfig{
+				ServiceName:             "test-otel-loglevel",
+				Version:                 "1.0.0",
+				OtelEndpoint:            "localhost:4318",
+				OtelTracingSampleRate:   1.0,
+				MetricsMode:             "pull",
+				MetricsPath:             "/metrics",
+				LogLevelEndpointEnabled: tt.enabled,
+				DebugEndpointsToken:     tt.token,
+			}
+
+			tel, err := InitOtelWithOptions(cfg, WithoutGlobals(), WithSpanExporter(tracetest.NewInMemoryExporter()))
+			if err != nil {
+				t.Fatalf("InitOtelWithOptions failed: %v", err)
+			}
+			defer func() { _ = tel.Shutdown(context.Background()) }()
+
+			url := "http://" + tel.MetricsAddr() + LogLevelPath
+			req, _ := http.NewRequest(http.MethodPut, url, strings.NewReader(`{"level":"warn"}`))
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("PUT %s failed: %v", LogLevelPath, err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, resp.StatusCode, body)
+			}
+			if logger.Level().String() != tt.wantLevel {
+				t.Errorf("expected default logger level %s, got %s", tt.wantLevel, logger.Level())
+			}
+		})
+	}
+}
+
 func TestInitOtel_DefaultsToPullWhenNoMode(t *testing.T) {
 	cfg := BaseConfig{
 		ServiceName:           "test-otel-defaults",
@@ -277,3 +429,74 @@ func TestInitOtel_BindFailure(t *testing.T) {
 		t.Fatalf("expected InitOtel to fail binding to occupied port %d, but it succeeded", tcpAddr.Port)
 	}
 }
+
+func TestNewTextMapPropagator(t *testing.T) {
+	defaults, err := newTextMapPropagator(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fields := defaults.Fields()
+	slices.Sort(fields)
+	if got := strings.Join(fields, ","); got != "baggage,traceparent,tracestate" {
+		t.Errorf("expected W3C trace context and baggage by default, got %s", got)
+	}
+
+	b3, err := newTextMapPropagator([]string{" B3 ", "tracecontext"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fields := b3.Fields(); !slices.Contains(fields, "x-b3-traceid") || !slices.Contains(fields, "traceparent") {
+		t.Errorf("expected b3 and traceparent fields, got %v", fields)
+	}
+
+	if _, err := newTextMapPropagator([]string{"unknown"}); err == nil {
+		t.Error("expected an error for an unknown propagator")
+	}
+}
+
+func TestInitOtel_ReleasesMetricsPortOnFailure(t *testing.T) {
+	// Reserve a free port, then let InitOtel bind it
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to reserve a port: %v", err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	_ = ln.Close()
+
+	cfg := BaseConfig{
+		ServiceName:         "test-otel-cleanup",
+		MetricsPort:         port,
+		MetricsMode:         "hybrid",
+		MetricsPath:         "/metrics",
+		MetricsProtocol:     "grpc",
+		MetricsPushEndpoint: "%%invalid",
+	}
+	// The push exporter fails after the metrics server started listening
+	if _, err := InitOtelWithOptions(cfg, WithoutGlobals(), WithSpanExporter(tracetest.NewInMemoryExporter())); err == nil {
+		t.Fatal("expected InitOtelWithOptions to fail with an invalid push endpoint")
+	}
+
+	ln, err = net.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", port))
+	if err != nil {
+		t.Fatalf("expected the metrics port to be released: %v", err)
+	}
+	_ = ln.Close()
+}
+
+func TestInitOtel_KeepsErrorHandlerOnFailure(t *testing.T) {
+	logger, logs := newObservedLogger()
+	cfg := BaseConfig{
+		ServiceName:         "test-otel-error-handler",
+		MetricsMode:         "push",
+		MetricsProtocol:     "grpc",
+		MetricsPushEndpoint: "%%invalid",
+	}
+	if _, err := InitOtelWithOptions(cfg, WithLogger(logger), WithSpanExporter(tracetest.NewInMemoryExporter())); err == nil {
+		t.Fatal("expected InitOtelWithOptions to fail with an invalid push endpoint")
+	}
+
+	otel.Handle(errors.New("after failed init"))
+	if logs.FilterMessage("OpenTelemetry error").Len() != 0 {
+		t.Error("expected a failed init to leave the global error handler untouched")
+	}
+}
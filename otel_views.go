@@ -0,0 +1,192 @@
+package observability
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"gopkg.in/yaml.v3"
+)
+
+// MetricView describes a metric view loaded from METRICS_VIEWS_FILE or METRICS_VIEWS
+type MetricView struct {
+	// Instrument selects instruments by name; "*" and "?" wildcards are supported
+	Instrument string `yaml:"instrument"`
+	// Meter restricts the view to instruments created by this meter (instrumentation scope)
+	Meter string `yaml:"meter"`
+	// Name renames the resulting stream; only allowed when Instrument has no wildcard
+	Name string `yaml:"name"`
+	// Aggregation is "default", "drop", "sum", "last_value", "explicit_bucket_histogram" or
+	// "base2_exponential_bucket_histogram"; it defaults to explicit buckets when Buckets is set
+	Aggregation string `yaml:"aggregation"`
+	// Buckets are the explicit histogram bucket boundaries, required by explicit_bucket_histogram
+	Buckets []float64 `yaml:"buckets"`
+	// MaxSize and MaxScale tune base2 exponential histograms (defaults 160 and 20)
+	MaxSize  int32 `yaml:"max_size"`
+	MaxScale int32 `yaml:"max_scale"`
+	// AttributesAllow keeps only these attribute keys; AttributesDeny removes these keys
+	AttributesAllow []string `yaml:"attributes_allow"`
+	AttributesDeny  []string `yaml:"attributes_deny"`
+}
+
+// MetricViews returns the views from METRICS_VIEWS_FILE followed by those in METRICS_VIEWS.
+// Both hold a YAML list of MetricView entries (JSON is accepted too).
+func (b *BaseConfig) MetricViews() ([]MetricView, error) {
+	var views []MetricView
+
+	if b.MetricsViewsFile != "" {
+		data, err := os.ReadFile(b.MetricsViewsFile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid METRICS_VIEWS_FILE: %w", err)
+		}
+		fileViews, err := parseMetricViews(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid METRICS_VIEWS_FILE %s: %w", b.MetricsViewsFile, err)
+		}
+		views = append(views, fileViews...)
+	}
+
+	if strings.TrimSpace(b.MetricsViews) != "" {
+		inlineViews, err := parseMetricViews([]byte(b.MetricsViews))
+		if err != nil {
+			return nil, fmt.Errorf("invalid METRICS_VIEWS: %w", err)
+		}
+		views = append(views, inlineViews...)
+	}
+
+	return views, nil
+}
+
+// parseMetricViews decodes a YAML list of views, rejecting unknown fields
+func parseMetricViews(data []byte) ([]MetricView, error) {
+	var views []MetricView
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&views); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	for i, v := range views {
+		if _, err := v.view(); err != nil {
+			return nil, fmt.Errorf("view %d: %w", i+1, err)
+		}
+	}
+	return views, nil
+}
+
+// newConfigViews builds the SDK views configured through METRICS_VIEWS_FILE and METRICS_VIEWS
+func newConfigViews(cfg BaseConfig) ([]sdkmetric.View, error) {
+	configured, err := cfg.MetricViews()
+	if err != nil {
+		return nil, err
+	}
+	views := make([]sdkmetric.View, 0, len(configured))
+	for _, v := range configured {
+		view, err := v.view()
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+// view converts the definition into an SDK view
+func (v MetricView) view() (sdkmetric.View, error) {
+	instrument := strings.TrimSpace(v.Instrument)
+	if instrument == "" {
+		return nil, fmt.Errorf("instrument is required")
+	}
+	if v.Name != "" && strings.ContainsAny(instrument, "*?") {
+		return nil, fmt.Errorf("instrument %q: name cannot be set when the instrument has wildcards", instrument)
+	}
+
+	aggregation, err := v.aggregation()
+	if err != nil {
+		return nil, fmt.Errorf("instrument %q: %w", instrument, err)
+	}
+
+	return sdkmetric.NewView(
+		sdkmetric.Instrument{
+			Name:  instrument,
+			Scope: instrumentation.Scope{Name: v.Meter},
+		},
+		sdkmetric.Stream{
+			Name:            v.Name,
+			Aggregation:     aggregation,
+			AttributeFilter: v.attributeFilter(),
+		},
+	), nil
+}
+
+// aggregation maps the configured aggregation name onto an SDK aggregation (nil keeps the default)
+func (v MetricView) aggregation() (sdkmetric.Aggregation, error) {
+	name := strings.ToLower(strings.TrimSpace(v.Aggregation))
+	if name == "" && len(v.Buckets) > 0 {
+		name = "explicit_bucket_histogram"
+	}
+
+	switch name {
+	case "", "default":
+		return nil, nil
+	case "drop":
+		return sdkmetric.AggregationDrop{}, nil
+	case "sum":
+		return sdkmetric.AggregationSum{}, nil
+	case "last_value":
+		return sdkmetric.AggregationLastValue{}, nil
+	case "explicit_bucket_histogram":
+		if len(v.Buckets) == 0 {
+			// Without boundaries the SDK would keep a single bucket and lose the distribution
+			return nil, fmt.Errorf("buckets are required for explicit_bucket_histogram (use 'default' to keep the SDK's boundaries)")
+		}
+		for i := 1; i < len(v.Buckets); i++ {
+			if v.Buckets[i] <= v.Buckets[i-1] {
+				return nil, fmt.Errorf("buckets must be in strictly increasing order")
+			}
+		}
+		return sdkmetric.AggregationExplicitBucketHistogram{Boundaries: v.Buckets}, nil
+	case "base2_exponential_bucket_histogram":
+		agg := sdkmetric.AggregationBase2ExponentialHistogram{MaxSize: 160, MaxScale: 20}
+		if v.MaxSize != 0 {
+			agg.MaxSize = v.MaxSize
+		}
+		if v.MaxScale != 0 {
+			agg.MaxScale = v.MaxScale
+		}
+		if agg.MaxSize < 0 || agg.MaxScale < -10 || agg.MaxScale > 20 {
+			return nil, fmt.Errorf("max_size must be positive and max_scale between -10 and 20")
+		}
+		return agg, nil
+	default:
+		return nil, fmt.Errorf("unknown aggregation %q (must be 'default', 'drop', 'sum', 'last_value', 'explicit_bucket_histogram' or 'base2_exponential_bucket_histogram')", v.Aggregation)
+	}
+}
+
+// attributeFilter returns the allow/deny filter for the view, or nil to keep every attribute
+func (v MetricView) attributeFilter() attribute.Filter {
+	if len(v.AttributesAllow) == 0 && len(v.AttributesDeny) == 0 {
+		return nil
+	}
+
+	allow := map[attribute.Key]bool{}
+	for _, key := range v.AttributesAllow {
+		allow[attribute.Key(key)] = true
+	}
+	deny := map[attribute.Key]bool{}
+	for _, key := range v.AttributesDeny {
+		deny[attribute.Key(key)] = true
+	}
+
+	return func(kv attribute.KeyValue) bool {
+		if len(allow) > 0 && !allow[kv.Key] {
+			return false
+		}
+		return !deny[kv.Key]
+	}
+}
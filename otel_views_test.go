@@ -0,0 +1,194 @@
+package observability
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestParseMetricViews(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    int
+		wantErr bool
+	}{
+		{name: "Empty", raw: "", want: 0},
+		{
+			name: "YAML",
+			raw: `
+- instrument: rpc.server.duration
+  buckets: [0.0001, 0.0005, 0.001, 0.005]
+- instrument: "debug.*"
+  aggregation: drop
+`,
+			want: 2,
+		},
+		{
+			name: "JSON",
+			raw:  `[{"instrument": "http.server.request.duration", "aggregation": "base2_exponential_bucket_histogram", "max_size": 80}]`,
+			want: 1,
+		},
+		{name: "Unknown Field", raw: `[{"instrument": "a", "bucket": [1]}]`, wantErr: true},
+		{name: "Missing Instrument", raw: `[{"aggregation": "drop"}]`, wantErr: true},
+		{name: "Unknown Aggregation", raw: `[{"instrument": "a", "aggregation": "median"}]`, wantErr: true},
+		{name: "Histogram Without Buckets", raw: `[{"instrument": "a", "aggregation": "explicit_bucket_histogram"}]`, wantErr: true},
+		{name: "Unsorted Buckets", raw: `[{"instrument": "a", "buckets": [1, 0.5]}]`, wantErr: true},
+		{name: "Invalid Max Scale", raw: `[{"instrument": "a", "aggregation": "base2_exponential_bucket_histogram", "max_scale": 30}]`, wantErr: true},
+		{name: "Rename With Wildcard", raw: `[{"instrument": "rpc.*", "name": "rpc"}]`, wantErr: true},
+		{name: "Not A List", raw: `instrument: a`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			views, err := parseMetricViews([]byte(tt.raw))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error for %q", tt.raw)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseMetricViews failed: %v", err)
+			}
+			if len(views) != tt.want {
+				t.Errorf("expected %d views, got %d", tt.want, len(views))
+			}
+		})
+	}
+}
+
+func TestMetricViewsSources(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "views.yaml")
+	if err := os.WriteFile(file, []byte("- instrument: from.file\n"), 0o600); err != nil {
+		t.Fatalf("failed to write views file: %v", err)
+	}
+
+	cfg := BaseConfig{MetricsViewsFile: file, MetricsViews: `[{"instrument": "from.env"}]`}
+	views, err := cfg.MetricViews()
+	if err != nil {
+		t.Fatalf("MetricViews failed: %v", err)
+	}
+	var names []string
+	for _, v := range views {
+		names = append(names, v.Instrument)
+	}
+	if !slices.Equal(names, []string{"from.file", "from.env"}) {
+		t.Errorf("expected file views before inline views, got %v", names)
+	}
+
+	cfg.MetricsViewsFile = filepath.Join(t.TempDir(), "missing.yaml")
+	if _, err := cfg.MetricViews(); err == nil {
+		t.Error("expected an error for a missing METRICS_VIEWS_FILE")
+	}
+}
+
+func TestInitOtel_MetricViews(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	cfg := BaseConfig{
+		ServiceName: "views-service",
+		MetricsViews: `
+- instrument: rpc.server.duration
+  buckets: [0.0001, 0.0005, 0.001]
+- instrument: db.latency
+  aggregation: base2_exponential_bucket_histogram
+- instrument: requests
+  attributes_deny: [user.id]
+- instrument: lookups
+  attributes_allow: [table]
+- instrument: "debug.*"
+  aggregation: drop
+`,
+	}
+
+	tel, err := InitOtelWithOptions(cfg,
+		WithoutGlobals(),
+		WithSpanExporter(tracetest.NewInMemoryExporter()),
+		WithMetricReader(reader),
+	)
+	if err != nil {
+		t.Fatalf("InitOtelWithOptions failed: %v", err)
+	}
+	defer func() { _ = tel.Shutdown(context.Background()) }()
+
+	ctx := context.Background()
+	meter := tel.MeterProvider().Meter("views-test")
+
+	duration, _ := meter.Float64Histogram("rpc.server.duration")
+	duration.Record(ctx, 0.0003)
+	latency, _ := meter.Float64Histogram("db.latency")
+	latency.Record(ctx, 0.02)
+	requests, _ := meter.Int64Counter("requests")
+	requests.Add(ctx, 1, metric.WithAttributes(attribute.String("user.id", "42"), attribute.String("route", "/a")))
+	lookups, _ := meter.Int64Counter("lookups")
+	lookups.Add(ctx, 1, metric.WithAttributes(attribute.String("table", "users"), attribute.String("key", "42")))
+	debug, _ := meter.Int64Counter("debug.cache_hits")
+	debug.Add(ctx, 1)
+
+	t.Run("Explicit Buckets", func(t *testing.T) {
+		m, ok := findMetric(t, reader, "rpc.server.duration")
+		if !ok {
+			t.Fatal("rpc.server.duration not found")
+		}
+		hist, ok := m.Data.(metricdata.Histogram[float64])
+		if !ok || len(hist.DataPoints) != 1 {
+			t.Fatalf("expected one histogram data point, got %T", m.Data)
+		}
+		if got := hist.DataPoints[0].Bounds; !slices.Equal(got, []float64{0.0001, 0.0005, 0.001}) {
+			t.Errorf("unexpected bucket bounds %v", got)
+		}
+	})
+
+	t.Run("Base2 Exponential Histogram", func(t *testing.T) {
+		m, ok := findMetric(t, reader, "db.latency")
+		if !ok {
+			t.Fatal("db.latency not found")
+		}
+		if _, ok := m.Data.(metricdata.ExponentialHistogram[float64]); !ok {
+			t.Errorf("expected an exponential histogram, got %T", m.Data)
+		}
+	})
+
+	attributeKeys := func(t *testing.T, name string) []string {
+		t.Helper()
+		m, ok := findMetric(t, reader, name)
+		if !ok {
+			t.Fatalf("%s not found", name)
+		}
+		sum, ok := m.Data.(metricdata.Sum[int64])
+		if !ok || len(sum.DataPoints) != 1 {
+			t.Fatalf("expected one sum data point, got %T", m.Data)
+		}
+		var keys []string
+		for _, kv := range sum.DataPoints[0].Attributes.ToSlice() {
+			keys = append(keys, string(kv.Key))
+		}
+		return keys
+	}
+
+	t.Run("Attribute Deny List", func(t *testing.T) {
+		if got := attributeKeys(t, "requests"); !slices.Equal(got, []string{"route"}) {
+			t.Errorf("expected only the route attribute, got %v", got)
+		}
+	})
+
+	t.Run("Attribute Allow List", func(t *testing.T) {
+		if got := attributeKeys(t, "lookups"); !slices.Equal(got, []string{"table"}) {
+			t.Errorf("expected only the table attribute, got %v", got)
+		}
+	})
+
+	t.Run("Dropped By Pattern", func(t *testing.T) {
+		if _, ok := findMetric(t, reader, "debug.cache_hits"); ok {
+			t.Error("expected debug.* instruments to be dropped")
+		}
+	})
+}